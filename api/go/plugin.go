@@ -9,6 +9,7 @@ import (
 	"log/slog"
 
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
@@ -28,6 +29,9 @@ type TaskParams[Params any] struct {
 	Params Params
 	Inputs []string
 	OutDir string
+
+	// The results of the tasks this task depends on, keyed by the id they were declared with.
+	Dependencies map[string]TaskResult
 }
 
 // Represents a backend capable of performing tasks.
@@ -35,14 +39,14 @@ type BonkBackend struct {
 	Name         string
 	Outputs      []string
 	ParamsSchema cue.Value
-	Exec         func(TaskParams[cue.Value]) error
+	Exec         func(context.Context, TaskParams[cue.Value]) (*TaskResult, error)
 }
 
 // Factory to create a new task backend.
 func NewBackend[Params any](
 	name string,
 	outputs []string,
-	exec func(*TaskParams[Params]) error,
+	exec func(context.Context, *TaskParams[Params]) (*TaskResult, error),
 ) BonkBackend {
 	zero := new(Params)
 
@@ -55,33 +59,94 @@ func NewBackend[Params any](
 		Name:         name,
 		Outputs:      outputs,
 		ParamsSchema: schema,
-		Exec: func(paramsCue TaskParams[cue.Value]) error {
+		Exec: func(ctx context.Context, paramsCue TaskParams[cue.Value]) (*TaskResult, error) {
 			params := new(TaskParams[Params])
 			params.Inputs = paramsCue.Inputs
 			params.OutDir = paramsCue.OutDir
+			params.Dependencies = paramsCue.Dependencies
 			err := paramsCue.Params.Decode(&params.Params)
 			if err != nil {
-				return fmt.Errorf("failed to decode task parameters: %w", err)
+				return nil, fmt.Errorf("failed to decode task parameters: %w", err)
+			}
+
+			return exec(ctx, params)
+		},
+	}
+}
+
+func (backend BonkBackend) register(server *bonkPluginServer) {
+	server.backends[backend.Name] = backend
+}
+
+// A task generated by a frontend.
+type TaskDescription struct {
+	ID           string
+	Backend      string
+	Inputs       []string
+	Params       any
+	Dependencies []string
+}
+
+// Represents a frontend capable of generating tasks from project configuration.
+type BonkFrontend struct {
+	Name         string
+	ConfigSchema cue.Value
+	Exec         func(context.Context, cue.Value) ([]TaskDescription, error)
+}
+
+// Factory to create a new task frontend.
+func NewFrontend[Config any](
+	name string,
+	exec func(context.Context, *Config) ([]TaskDescription, error),
+) BonkFrontend {
+	zero := new(Config)
+
+	schema := cuectx.EncodeType(*zero)
+	if schema.Err() != nil {
+		panic(schema.Err())
+	}
+
+	return BonkFrontend{
+		Name:         name,
+		ConfigSchema: schema,
+		Exec: func(ctx context.Context, configCue cue.Value) ([]TaskDescription, error) {
+			config := new(Config)
+			err := configCue.Decode(config)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode frontend configuration: %w", err)
 			}
 
-			return exec(params)
+			return exec(ctx, config)
 		},
 	}
 }
 
+func (frontend BonkFrontend) register(server *bonkPluginServer) {
+	server.frontends[frontend.Name] = frontend
+}
+
+// A backend or frontend which may be served by a plugin.
+type Component interface {
+	register(server *bonkPluginServer)
+}
+
 // Call from main() to start the plugin gRPC server.
-func Serve(backends ...BonkBackend) {
-	backendMap := make(map[string]BonkBackend, len(backends))
-	for _, backend := range backends {
-		backendMap[backend.Name] = backend
+func Serve(components ...Component) {
+	server := &bonkPluginServer{
+		backends:  make(map[string]BonkBackend),
+		frontends: make(map[string]BonkFrontend),
 	}
+	for _, component := range components {
+		component.register(server)
+	}
+
+	// Hashing the executable may take longer than bonk waits for the plugin to be configured
+	buildIdentity()
 
 	goplugin.Serve(&goplugin.ServeConfig{
 		HandshakeConfig: Handshake,
 		Plugins: map[string]goplugin.Plugin{
-			PluginType: &bonkPluginServer{
-				backends: backendMap,
-			},
+			PluginType: server,
 		},
 		GRPCServer: goplugin.DefaultGRPCServer,
 		Logger:     shclog.New(slog.Default()),
@@ -102,13 +167,15 @@ type bonkPluginServer struct {
 	goplugin.NetRPCUnsupportedPlugin
 	goplugin.GRPCPlugin
 
-	backends map[string]BonkBackend
+	backends  map[string]BonkBackend
+	frontends map[string]BonkFrontend
 }
 
 func (p *bonkPluginServer) GRPCServer(_ *goplugin.GRPCBroker, s *grpc.Server) error {
 	bonkv0.RegisterBonkPluginServiceServer(s, &grpcServer{
 		decodeCodec: gocodec.New(cuectx, &gocodec.Config{}),
 		backends:    p.backends,
+		frontends:   p.frontends,
 	})
 
 	return nil
@@ -120,14 +187,26 @@ type grpcServer struct {
 
 	decodeCodec *gocodec.Codec
 	backends    map[string]BonkBackend
+	frontends   map[string]BonkFrontend
 }
 
 func (s *grpcServer) ConfigurePlugin(
 	ctx context.Context,
 	req *bonkv0.ConfigurePluginRequest,
 ) (*bonkv0.ConfigurePluginResponse, error) {
+	version, buildDigest := buildIdentity()
+
 	respBuilder := bonkv0.ConfigurePluginResponse_builder{
-		Backends: make(map[string]*bonkv0.ConfigurePluginResponse_BackendDescription, len(s.backends)),
+		Version:     &version,
+		BuildDigest: &buildDigest,
+		Backends: make(
+			map[string]*bonkv0.ConfigurePluginResponse_BackendDescription,
+			len(s.backends),
+		),
+		Frontends: make(
+			map[string]*bonkv0.ConfigurePluginResponse_FrontendDescription,
+			len(s.frontends),
+		),
 	}
 
 	for name, backend := range s.backends {
@@ -136,6 +215,64 @@ func (s *grpcServer) ConfigurePlugin(
 		}.Build()
 	}
 
+	for name := range s.frontends {
+		respBuilder.Frontends[name] = bonkv0.ConfigurePluginResponse_FrontendDescription_builder{}.Build()
+	}
+
+	return respBuilder.Build(), nil
+}
+
+func (s *grpcServer) GenerateTasks(
+	ctx context.Context,
+	req *bonkv0.GenerateTasksRequest,
+) (*bonkv0.GenerateTasksResponse, error) {
+	frontend, ok := s.frontends[req.GetFrontend()]
+	if !ok {
+		return nil, fmt.Errorf("frontend %s is not registered to this plugin", req.GetFrontend())
+	}
+
+	err := s.decodeCodec.Validate(frontend.ConfigSchema, req.GetConfiguration())
+	if err != nil {
+		return nil, fmt.Errorf(
+			"configuration %s doesn't match required schema %s",
+			req.GetConfiguration(),
+			frontend.ConfigSchema,
+		)
+	}
+
+	config, err := s.decodeCodec.Decode(req.GetConfiguration())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	tasks, err := frontend.Exec(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	respBuilder := bonkv0.GenerateTasksResponse_builder{
+		Tasks: make([]*bonkv0.TaskDescription, len(tasks)),
+	}
+
+	for idx, tsk := range tasks {
+		taskBuilder := bonkv0.TaskDescription_builder{
+			Id:           &tsk.ID,
+			Backend:      &tsk.Backend,
+			Inputs:       tsk.Inputs,
+			Parameters:   &structpb.Struct{},
+			Dependencies: tsk.Dependencies,
+		}
+
+		if tsk.Params != nil {
+			err = cuectx.Encode(tsk.Params).Decode(taskBuilder.Parameters)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode parameters of task %s: %w", tsk.ID, err)
+			}
+		}
+
+		respBuilder.Tasks[idx] = taskBuilder.Build()
+	}
+
 	return respBuilder.Build(), nil
 }
 
@@ -149,9 +286,14 @@ func (s *grpcServer) PerformTask(
 	}
 
 	params := TaskParams[cue.Value]{
-		Params: cue.Value{},
-		Inputs: req.GetInputs(),
-		OutDir: req.GetOutDirectory(),
+		Params:       cue.Value{},
+		Inputs:       req.GetInputs(),
+		OutDir:       req.GetOutDirectory(),
+		Dependencies: make(map[string]TaskResult, len(req.GetDependencies())),
+	}
+
+	for name, result := range req.GetDependencies() {
+		params.Dependencies[name] = TaskResultFromProto(result)
 	}
 
 	err := s.decodeCodec.Validate(backend.ParamsSchema, req.GetParameters())
@@ -168,10 +310,12 @@ func (s *grpcServer) PerformTask(
 		return nil, fmt.Errorf("failed to decode parameters: %w", err)
 	}
 
-	err = backend.Exec(params)
+	result, err := backend.Exec(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
-	return bonkv0.PerformTaskResponse_builder{}.Build(), nil
+	return bonkv0.PerformTaskResponse_builder{
+		Result: result.ToProto(),
+	}.Build(), nil
 }
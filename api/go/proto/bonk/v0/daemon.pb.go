@@ -0,0 +1,928 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: bonk/v0/daemon.proto
+
+package v0
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BuildRequest struct {
+	state                          protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Targets             []string               `protobuf:"bytes,1,rep,name=targets"`
+	xxx_hidden_KeepGoing           bool                   `protobuf:"varint,2,opt,name=keep_going,json=keepGoing"`
+	xxx_hidden_StrictOutputs       bool                   `protobuf:"varint,3,opt,name=strict_outputs,json=strictOutputs"`
+	xxx_hidden_NoCache             bool                   `protobuf:"varint,4,opt,name=no_cache,json=noCache"`
+	xxx_hidden_ReverseDependencies bool                   `protobuf:"varint,5,opt,name=reverse_dependencies,json=reverseDependencies"`
+	xxx_hidden_Profile             *string                `protobuf:"bytes,6,opt,name=profile"`
+	xxx_hidden_Concurrency         uint32                 `protobuf:"varint,7,opt,name=concurrency"`
+	xxx_hidden_RemoteCache         *string                `protobuf:"bytes,8,opt,name=remote_cache,json=remoteCache"`
+	xxx_hidden_CacheDir            *string                `protobuf:"bytes,9,opt,name=cache_dir,json=cacheDir"`
+	xxx_hidden_PluginDir           *string                `protobuf:"bytes,10,opt,name=plugin_dir,json=pluginDir"`
+	XXX_raceDetectHookData         protoimpl.RaceDetectHookData
+	XXX_presence                   [1]uint32
+	unknownFields                  protoimpl.UnknownFields
+	sizeCache                      protoimpl.SizeCache
+}
+
+func (x *BuildRequest) Reset() {
+	*x = BuildRequest{}
+	mi := &file_bonk_v0_daemon_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildRequest) ProtoMessage() {}
+
+func (x *BuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_daemon_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BuildRequest) GetTargets() []string {
+	if x != nil {
+		return x.xxx_hidden_Targets
+	}
+	return nil
+}
+
+func (x *BuildRequest) GetKeepGoing() bool {
+	if x != nil {
+		return x.xxx_hidden_KeepGoing
+	}
+	return false
+}
+
+func (x *BuildRequest) GetStrictOutputs() bool {
+	if x != nil {
+		return x.xxx_hidden_StrictOutputs
+	}
+	return false
+}
+
+func (x *BuildRequest) GetNoCache() bool {
+	if x != nil {
+		return x.xxx_hidden_NoCache
+	}
+	return false
+}
+
+func (x *BuildRequest) GetReverseDependencies() bool {
+	if x != nil {
+		return x.xxx_hidden_ReverseDependencies
+	}
+	return false
+}
+
+func (x *BuildRequest) GetProfile() string {
+	if x != nil {
+		if x.xxx_hidden_Profile != nil {
+			return *x.xxx_hidden_Profile
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BuildRequest) GetConcurrency() uint32 {
+	if x != nil {
+		return x.xxx_hidden_Concurrency
+	}
+	return 0
+}
+
+func (x *BuildRequest) GetRemoteCache() string {
+	if x != nil {
+		if x.xxx_hidden_RemoteCache != nil {
+			return *x.xxx_hidden_RemoteCache
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BuildRequest) GetCacheDir() string {
+	if x != nil {
+		if x.xxx_hidden_CacheDir != nil {
+			return *x.xxx_hidden_CacheDir
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BuildRequest) GetPluginDir() string {
+	if x != nil {
+		if x.xxx_hidden_PluginDir != nil {
+			return *x.xxx_hidden_PluginDir
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BuildRequest) SetTargets(v []string) {
+	x.xxx_hidden_Targets = v
+}
+
+func (x *BuildRequest) SetKeepGoing(v bool) {
+	x.xxx_hidden_KeepGoing = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 10)
+}
+
+func (x *BuildRequest) SetStrictOutputs(v bool) {
+	x.xxx_hidden_StrictOutputs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 10)
+}
+
+func (x *BuildRequest) SetNoCache(v bool) {
+	x.xxx_hidden_NoCache = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 10)
+}
+
+func (x *BuildRequest) SetReverseDependencies(v bool) {
+	x.xxx_hidden_ReverseDependencies = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 10)
+}
+
+func (x *BuildRequest) SetProfile(v string) {
+	x.xxx_hidden_Profile = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 10)
+}
+
+func (x *BuildRequest) SetConcurrency(v uint32) {
+	x.xxx_hidden_Concurrency = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 10)
+}
+
+func (x *BuildRequest) SetRemoteCache(v string) {
+	x.xxx_hidden_RemoteCache = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 10)
+}
+
+func (x *BuildRequest) SetCacheDir(v string) {
+	x.xxx_hidden_CacheDir = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 10)
+}
+
+func (x *BuildRequest) SetPluginDir(v string) {
+	x.xxx_hidden_PluginDir = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 10)
+}
+
+func (x *BuildRequest) HasKeepGoing() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *BuildRequest) HasStrictOutputs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *BuildRequest) HasNoCache() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *BuildRequest) HasReverseDependencies() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *BuildRequest) HasProfile() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *BuildRequest) HasConcurrency() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *BuildRequest) HasRemoteCache() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *BuildRequest) HasCacheDir() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *BuildRequest) HasPluginDir() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *BuildRequest) ClearKeepGoing() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_KeepGoing = false
+}
+
+func (x *BuildRequest) ClearStrictOutputs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_StrictOutputs = false
+}
+
+func (x *BuildRequest) ClearNoCache() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_NoCache = false
+}
+
+func (x *BuildRequest) ClearReverseDependencies() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_ReverseDependencies = false
+}
+
+func (x *BuildRequest) ClearProfile() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Profile = nil
+}
+
+func (x *BuildRequest) ClearConcurrency() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_Concurrency = 0
+}
+
+func (x *BuildRequest) ClearRemoteCache() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_RemoteCache = nil
+}
+
+func (x *BuildRequest) ClearCacheDir() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_CacheDir = nil
+}
+
+func (x *BuildRequest) ClearPluginDir() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_PluginDir = nil
+}
+
+type BuildRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Patterns selecting the tasks to build, or none to build every task.
+	Targets             []string
+	KeepGoing           *bool
+	StrictOutputs       *bool
+	NoCache             *bool
+	ReverseDependencies *bool
+	// The file to write a trace of the build to, or empty to not profile it.
+	Profile *string
+	// The number of tasks to run at once.
+	Concurrency *uint32
+	// The caches and plugins the client is configured to use. The daemon refuses builds which
+	// don't match its own configuration, since it keeps them open between builds.
+	RemoteCache *string
+	CacheDir    *string
+	PluginDir   *string
+}
+
+func (b0 BuildRequest_builder) Build() *BuildRequest {
+	m0 := &BuildRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Targets = b.Targets
+	if b.KeepGoing != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 10)
+		x.xxx_hidden_KeepGoing = *b.KeepGoing
+	}
+	if b.StrictOutputs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 10)
+		x.xxx_hidden_StrictOutputs = *b.StrictOutputs
+	}
+	if b.NoCache != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 10)
+		x.xxx_hidden_NoCache = *b.NoCache
+	}
+	if b.ReverseDependencies != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 10)
+		x.xxx_hidden_ReverseDependencies = *b.ReverseDependencies
+	}
+	if b.Profile != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 10)
+		x.xxx_hidden_Profile = b.Profile
+	}
+	if b.Concurrency != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 10)
+		x.xxx_hidden_Concurrency = *b.Concurrency
+	}
+	if b.RemoteCache != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 10)
+		x.xxx_hidden_RemoteCache = b.RemoteCache
+	}
+	if b.CacheDir != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 10)
+		x.xxx_hidden_CacheDir = b.CacheDir
+	}
+	if b.PluginDir != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 10)
+		x.xxx_hidden_PluginDir = b.PluginDir
+	}
+	return m0
+}
+
+type LogRecord struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Severity    Diagnostic_Severity    `protobuf:"varint,1,opt,name=severity,enum=bonk.v0.Diagnostic_Severity"`
+	xxx_hidden_Message     *string                `protobuf:"bytes,2,opt,name=message"`
+	xxx_hidden_Attributes  map[string]string      `protobuf:"bytes,3,rep,name=attributes" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *LogRecord) Reset() {
+	*x = LogRecord{}
+	mi := &file_bonk_v0_daemon_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogRecord) ProtoMessage() {}
+
+func (x *LogRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_daemon_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *LogRecord) GetSeverity() Diagnostic_Severity {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Severity
+		}
+	}
+	return Diagnostic_SEVERITY_UNSPECIFIED
+}
+
+func (x *LogRecord) GetMessage() string {
+	if x != nil {
+		if x.xxx_hidden_Message != nil {
+			return *x.xxx_hidden_Message
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *LogRecord) GetAttributes() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Attributes
+	}
+	return nil
+}
+
+func (x *LogRecord) SetSeverity(v Diagnostic_Severity) {
+	x.xxx_hidden_Severity = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *LogRecord) SetMessage(v string) {
+	x.xxx_hidden_Message = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *LogRecord) SetAttributes(v map[string]string) {
+	x.xxx_hidden_Attributes = v
+}
+
+func (x *LogRecord) HasSeverity() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *LogRecord) HasMessage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *LogRecord) ClearSeverity() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Severity = Diagnostic_SEVERITY_UNSPECIFIED
+}
+
+func (x *LogRecord) ClearMessage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Message = nil
+}
+
+type LogRecord_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Severity   *Diagnostic_Severity
+	Message    *string
+	Attributes map[string]string
+}
+
+func (b0 LogRecord_builder) Build() *LogRecord {
+	m0 := &LogRecord{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Severity != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Severity = *b.Severity
+	}
+	if b.Message != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Message = b.Message
+	}
+	x.xxx_hidden_Attributes = b.Attributes
+	return m0
+}
+
+type BuildResult struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Error       *string                `protobuf:"bytes,1,opt,name=error"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *BuildResult) Reset() {
+	*x = BuildResult{}
+	mi := &file_bonk_v0_daemon_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildResult) ProtoMessage() {}
+
+func (x *BuildResult) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_daemon_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BuildResult) GetError() string {
+	if x != nil {
+		if x.xxx_hidden_Error != nil {
+			return *x.xxx_hidden_Error
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BuildResult) SetError(v string) {
+	x.xxx_hidden_Error = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *BuildResult) HasError() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *BuildResult) ClearError() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Error = nil
+}
+
+type BuildResult_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// The reason the build failed, or empty if it succeeded.
+	Error *string
+}
+
+func (b0 BuildResult_builder) Build() *BuildResult {
+	m0 := &BuildResult{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Error != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Error = b.Error
+	}
+	return m0
+}
+
+type BuildResponse struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Event isBuildResponse_Event  `protobuf_oneof:"event"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BuildResponse) Reset() {
+	*x = BuildResponse{}
+	mi := &file_bonk_v0_daemon_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildResponse) ProtoMessage() {}
+
+func (x *BuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_daemon_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BuildResponse) GetLog() *LogRecord {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Event.(*buildResponse_Log); ok {
+			return x.Log
+		}
+	}
+	return nil
+}
+
+func (x *BuildResponse) GetResult() *BuildResult {
+	if x != nil {
+		if x, ok := x.xxx_hidden_Event.(*buildResponse_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+func (x *BuildResponse) SetLog(v *LogRecord) {
+	if v == nil {
+		x.xxx_hidden_Event = nil
+		return
+	}
+	x.xxx_hidden_Event = &buildResponse_Log{v}
+}
+
+func (x *BuildResponse) SetResult(v *BuildResult) {
+	if v == nil {
+		x.xxx_hidden_Event = nil
+		return
+	}
+	x.xxx_hidden_Event = &buildResponse_Result{v}
+}
+
+func (x *BuildResponse) HasEvent() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Event != nil
+}
+
+func (x *BuildResponse) HasLog() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Event.(*buildResponse_Log)
+	return ok
+}
+
+func (x *BuildResponse) HasResult() bool {
+	if x == nil {
+		return false
+	}
+	_, ok := x.xxx_hidden_Event.(*buildResponse_Result)
+	return ok
+}
+
+func (x *BuildResponse) ClearEvent() {
+	x.xxx_hidden_Event = nil
+}
+
+func (x *BuildResponse) ClearLog() {
+	if _, ok := x.xxx_hidden_Event.(*buildResponse_Log); ok {
+		x.xxx_hidden_Event = nil
+	}
+}
+
+func (x *BuildResponse) ClearResult() {
+	if _, ok := x.xxx_hidden_Event.(*buildResponse_Result); ok {
+		x.xxx_hidden_Event = nil
+	}
+}
+
+const BuildResponse_Event_not_set_case case_BuildResponse_Event = 0
+const BuildResponse_Log_case case_BuildResponse_Event = 1
+const BuildResponse_Result_case case_BuildResponse_Event = 2
+
+func (x *BuildResponse) WhichEvent() case_BuildResponse_Event {
+	if x == nil {
+		return BuildResponse_Event_not_set_case
+	}
+	switch x.xxx_hidden_Event.(type) {
+	case *buildResponse_Log:
+		return BuildResponse_Log_case
+	case *buildResponse_Result:
+		return BuildResponse_Result_case
+	default:
+		return BuildResponse_Event_not_set_case
+	}
+}
+
+type BuildResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// Fields of oneof xxx_hidden_Event:
+	// A message logged by the daemon while building.
+	Log *LogRecord
+	// The outcome of the build, sent last.
+	Result *BuildResult
+	// -- end of xxx_hidden_Event
+}
+
+func (b0 BuildResponse_builder) Build() *BuildResponse {
+	m0 := &BuildResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Log != nil {
+		x.xxx_hidden_Event = &buildResponse_Log{b.Log}
+	}
+	if b.Result != nil {
+		x.xxx_hidden_Event = &buildResponse_Result{b.Result}
+	}
+	return m0
+}
+
+type case_BuildResponse_Event protoreflect.FieldNumber
+
+func (x case_BuildResponse_Event) String() string {
+	md := file_bonk_v0_daemon_proto_msgTypes[3].Descriptor()
+	if x == 0 {
+		return "not set"
+	}
+	return protoimpl.X.MessageFieldStringOf(md, protoreflect.FieldNumber(x))
+}
+
+type isBuildResponse_Event interface {
+	isBuildResponse_Event()
+}
+
+type buildResponse_Log struct {
+	// A message logged by the daemon while building.
+	Log *LogRecord `protobuf:"bytes,1,opt,name=log,oneof"`
+}
+
+type buildResponse_Result struct {
+	// The outcome of the build, sent last.
+	Result *BuildResult `protobuf:"bytes,2,opt,name=result,oneof"`
+}
+
+func (*buildResponse_Log) isBuildResponse_Event() {}
+
+func (*buildResponse_Result) isBuildResponse_Event() {}
+
+type ShutdownRequest struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShutdownRequest) Reset() {
+	*x = ShutdownRequest{}
+	mi := &file_bonk_v0_daemon_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShutdownRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShutdownRequest) ProtoMessage() {}
+
+func (x *ShutdownRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_daemon_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type ShutdownRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 ShutdownRequest_builder) Build() *ShutdownRequest {
+	m0 := &ShutdownRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+type ShutdownResponse struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShutdownResponse) Reset() {
+	*x = ShutdownResponse{}
+	mi := &file_bonk_v0_daemon_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShutdownResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShutdownResponse) ProtoMessage() {}
+
+func (x *ShutdownResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_daemon_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type ShutdownResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 ShutdownResponse_builder) Build() *ShutdownResponse {
+	m0 := &ShutdownResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
+var File_bonk_v0_daemon_proto protoreflect.FileDescriptor
+
+const file_bonk_v0_daemon_proto_rawDesc = "" +
+	"\n" +
+	"\x14bonk/v0/daemon.proto\x12\abonk.v0\x1a\x14bonk/v0/plugin.proto\"\xd7\x02\n" +
+	"\fBuildRequest\x12\x18\n" +
+	"\atargets\x18\x01 \x03(\tR\atargets\x12\x1d\n" +
+	"\n" +
+	"keep_going\x18\x02 \x01(\bR\tkeepGoing\x12%\n" +
+	"\x0estrict_outputs\x18\x03 \x01(\bR\rstrictOutputs\x12\x19\n" +
+	"\bno_cache\x18\x04 \x01(\bR\anoCache\x121\n" +
+	"\x14reverse_dependencies\x18\x05 \x01(\bR\x13reverseDependencies\x12\x18\n" +
+	"\aprofile\x18\x06 \x01(\tR\aprofile\x12 \n" +
+	"\vconcurrency\x18\a \x01(\rR\vconcurrency\x12!\n" +
+	"\fremote_cache\x18\b \x01(\tR\vremoteCache\x12\x1b\n" +
+	"\tcache_dir\x18\t \x01(\tR\bcacheDir\x12\x1d\n" +
+	"\n" +
+	"plugin_dir\x18\n" +
+	" \x01(\tR\tpluginDir\"\xe2\x01\n" +
+	"\tLogRecord\x128\n" +
+	"\bseverity\x18\x01 \x01(\x0e2\x1c.bonk.v0.Diagnostic.SeverityR\bseverity\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12B\n" +
+	"\n" +
+	"attributes\x18\x03 \x03(\v2\".bonk.v0.LogRecord.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"#\n" +
+	"\vBuildResult\x12\x14\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error\"p\n" +
+	"\rBuildResponse\x12&\n" +
+	"\x03log\x18\x01 \x01(\v2\x12.bonk.v0.LogRecordH\x00R\x03log\x12.\n" +
+	"\x06result\x18\x02 \x01(\v2\x14.bonk.v0.BuildResultH\x00R\x06resultB\a\n" +
+	"\x05event\"\x11\n" +
+	"\x0fShutdownRequest\"\x12\n" +
+	"\x10ShutdownResponse2\x8e\x01\n" +
+	"\x11BonkDaemonService\x128\n" +
+	"\x05Build\x12\x15.bonk.v0.BuildRequest\x1a\x16.bonk.v0.BuildResponse0\x01\x12?\n" +
+	"\bShutdown\x12\x18.bonk.v0.ShutdownRequest\x1a\x19.bonk.v0.ShutdownResponseB\x80\x01\n" +
+	"\vcom.bonk.v0B\vDaemonProtoP\x01Z\"go.bonk.build/api/go/proto/bonk/v0\xa2\x02\x03BVX\xaa\x02\aBonk.V0\xca\x02\aBonk\\V0\xe2\x02\x13Bonk\\V0\\GPBMetadata\xea\x02\bBonk::V0\x92\x03\x02\b\x01b\beditionsp\xe8\a"
+
+var file_bonk_v0_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_bonk_v0_daemon_proto_goTypes = []any{
+	(*BuildRequest)(nil),     // 0: bonk.v0.BuildRequest
+	(*LogRecord)(nil),        // 1: bonk.v0.LogRecord
+	(*BuildResult)(nil),      // 2: bonk.v0.BuildResult
+	(*BuildResponse)(nil),    // 3: bonk.v0.BuildResponse
+	(*ShutdownRequest)(nil),  // 4: bonk.v0.ShutdownRequest
+	(*ShutdownResponse)(nil), // 5: bonk.v0.ShutdownResponse
+	nil,                      // 6: bonk.v0.LogRecord.AttributesEntry
+	(Diagnostic_Severity)(0), // 7: bonk.v0.Diagnostic.Severity
+}
+var file_bonk_v0_daemon_proto_depIdxs = []int32{
+	7, // 0: bonk.v0.LogRecord.severity:type_name -> bonk.v0.Diagnostic.Severity
+	6, // 1: bonk.v0.LogRecord.attributes:type_name -> bonk.v0.LogRecord.AttributesEntry
+	1, // 2: bonk.v0.BuildResponse.log:type_name -> bonk.v0.LogRecord
+	2, // 3: bonk.v0.BuildResponse.result:type_name -> bonk.v0.BuildResult
+	0, // 4: bonk.v0.BonkDaemonService.Build:input_type -> bonk.v0.BuildRequest
+	4, // 5: bonk.v0.BonkDaemonService.Shutdown:input_type -> bonk.v0.ShutdownRequest
+	3, // 6: bonk.v0.BonkDaemonService.Build:output_type -> bonk.v0.BuildResponse
+	5, // 7: bonk.v0.BonkDaemonService.Shutdown:output_type -> bonk.v0.ShutdownResponse
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_bonk_v0_daemon_proto_init() }
+func file_bonk_v0_daemon_proto_init() {
+	if File_bonk_v0_daemon_proto != nil {
+		return
+	}
+	file_bonk_v0_plugin_proto_init()
+	file_bonk_v0_daemon_proto_msgTypes[3].OneofWrappers = []any{
+		(*buildResponse_Log)(nil),
+		(*buildResponse_Result)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bonk_v0_daemon_proto_rawDesc), len(file_bonk_v0_daemon_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_bonk_v0_daemon_proto_goTypes,
+		DependencyIndexes: file_bonk_v0_daemon_proto_depIdxs,
+		MessageInfos:      file_bonk_v0_daemon_proto_msgTypes,
+	}.Build()
+	File_bonk_v0_daemon_proto = out.File
+	file_bonk_v0_daemon_proto_goTypes = nil
+	file_bonk_v0_daemon_proto_depIdxs = nil
+}
@@ -0,0 +1,163 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: bonk/v0/daemon.proto
+
+package v0
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BonkDaemonService_Build_FullMethodName    = "/bonk.v0.BonkDaemonService/Build"
+	BonkDaemonService_Shutdown_FullMethodName = "/bonk.v0.BonkDaemonService/Shutdown"
+)
+
+// BonkDaemonServiceClient is the client API for BonkDaemonService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BonkDaemonServiceClient interface {
+	Build(ctx context.Context, in *BuildRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BuildResponse], error)
+	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
+}
+
+type bonkDaemonServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBonkDaemonServiceClient(cc grpc.ClientConnInterface) BonkDaemonServiceClient {
+	return &bonkDaemonServiceClient{cc}
+}
+
+func (c *bonkDaemonServiceClient) Build(ctx context.Context, in *BuildRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BuildResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BonkDaemonService_ServiceDesc.Streams[0], BonkDaemonService_Build_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BuildRequest, BuildResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BonkDaemonService_BuildClient = grpc.ServerStreamingClient[BuildResponse]
+
+func (c *bonkDaemonServiceClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShutdownResponse)
+	err := c.cc.Invoke(ctx, BonkDaemonService_Shutdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BonkDaemonServiceServer is the server API for BonkDaemonService service.
+// All implementations must embed UnimplementedBonkDaemonServiceServer
+// for forward compatibility.
+type BonkDaemonServiceServer interface {
+	Build(*BuildRequest, grpc.ServerStreamingServer[BuildResponse]) error
+	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
+	mustEmbedUnimplementedBonkDaemonServiceServer()
+}
+
+// UnimplementedBonkDaemonServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBonkDaemonServiceServer struct{}
+
+func (UnimplementedBonkDaemonServiceServer) Build(*BuildRequest, grpc.ServerStreamingServer[BuildResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Build not implemented")
+}
+func (UnimplementedBonkDaemonServiceServer) Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
+}
+func (UnimplementedBonkDaemonServiceServer) mustEmbedUnimplementedBonkDaemonServiceServer() {}
+func (UnimplementedBonkDaemonServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeBonkDaemonServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BonkDaemonServiceServer will
+// result in compilation errors.
+type UnsafeBonkDaemonServiceServer interface {
+	mustEmbedUnimplementedBonkDaemonServiceServer()
+}
+
+func RegisterBonkDaemonServiceServer(s grpc.ServiceRegistrar, srv BonkDaemonServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBonkDaemonServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BonkDaemonService_ServiceDesc, srv)
+}
+
+func _BonkDaemonService_Build_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BuildRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BonkDaemonServiceServer).Build(m, &grpc.GenericServerStream[BuildRequest, BuildResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BonkDaemonService_BuildServer = grpc.ServerStreamingServer[BuildResponse]
+
+func _BonkDaemonService_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShutdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BonkDaemonServiceServer).Shutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BonkDaemonService_Shutdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BonkDaemonServiceServer).Shutdown(ctx, req.(*ShutdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BonkDaemonService_ServiceDesc is the grpc.ServiceDesc for BonkDaemonService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BonkDaemonService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bonk.v0.BonkDaemonService",
+	HandlerType: (*BonkDaemonServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Shutdown",
+			Handler:    _BonkDaemonService_Shutdown_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Build",
+			Handler:       _BonkDaemonService_Build_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "bonk/v0/daemon.proto",
+}
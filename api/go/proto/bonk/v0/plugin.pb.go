@@ -21,6 +21,56 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Diagnostic_Severity int32
+
+const (
+	Diagnostic_SEVERITY_UNSPECIFIED Diagnostic_Severity = 0
+	Diagnostic_SEVERITY_DEBUG       Diagnostic_Severity = 1
+	Diagnostic_SEVERITY_INFO        Diagnostic_Severity = 2
+	Diagnostic_SEVERITY_WARNING     Diagnostic_Severity = 3
+	Diagnostic_SEVERITY_ERROR       Diagnostic_Severity = 4
+)
+
+// Enum value maps for Diagnostic_Severity.
+var (
+	Diagnostic_Severity_name = map[int32]string{
+		0: "SEVERITY_UNSPECIFIED",
+		1: "SEVERITY_DEBUG",
+		2: "SEVERITY_INFO",
+		3: "SEVERITY_WARNING",
+		4: "SEVERITY_ERROR",
+	}
+	Diagnostic_Severity_value = map[string]int32{
+		"SEVERITY_UNSPECIFIED": 0,
+		"SEVERITY_DEBUG":       1,
+		"SEVERITY_INFO":        2,
+		"SEVERITY_WARNING":     3,
+		"SEVERITY_ERROR":       4,
+	}
+)
+
+func (x Diagnostic_Severity) Enum() *Diagnostic_Severity {
+	p := new(Diagnostic_Severity)
+	*p = x
+	return p
+}
+
+func (x Diagnostic_Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Diagnostic_Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_bonk_v0_plugin_proto_enumTypes[0].Descriptor()
+}
+
+func (Diagnostic_Severity) Type() protoreflect.EnumType {
+	return &file_bonk_v0_plugin_proto_enumTypes[0]
+}
+
+func (x Diagnostic_Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
 type ConfigurePluginRequest struct {
 	state         protoimpl.MessageState `protogen:"opaque.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -65,10 +115,15 @@ func (b0 ConfigurePluginRequest_builder) Build() *ConfigurePluginRequest {
 }
 
 type ConfigurePluginResponse struct {
-	state               protoimpl.MessageState                                 `protogen:"opaque.v1"`
-	xxx_hidden_Backends map[string]*ConfigurePluginResponse_BackendDescription `protobuf:"bytes,1,rep,name=backends" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	state                  protoimpl.MessageState                                  `protogen:"opaque.v1"`
+	xxx_hidden_Backends    map[string]*ConfigurePluginResponse_BackendDescription  `protobuf:"bytes,1,rep,name=backends" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_Frontends   map[string]*ConfigurePluginResponse_FrontendDescription `protobuf:"bytes,2,rep,name=frontends" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	xxx_hidden_Version     *string                                                 `protobuf:"bytes,3,opt,name=version"`
+	xxx_hidden_BuildDigest *string                                                 `protobuf:"bytes,4,opt,name=build_digest,json=buildDigest"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *ConfigurePluginResponse) Reset() {
@@ -103,14 +158,85 @@ func (x *ConfigurePluginResponse) GetBackends() map[string]*ConfigurePluginRespo
 	return nil
 }
 
+func (x *ConfigurePluginResponse) GetFrontends() map[string]*ConfigurePluginResponse_FrontendDescription {
+	if x != nil {
+		return x.xxx_hidden_Frontends
+	}
+	return nil
+}
+
+func (x *ConfigurePluginResponse) GetVersion() string {
+	if x != nil {
+		if x.xxx_hidden_Version != nil {
+			return *x.xxx_hidden_Version
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ConfigurePluginResponse) GetBuildDigest() string {
+	if x != nil {
+		if x.xxx_hidden_BuildDigest != nil {
+			return *x.xxx_hidden_BuildDigest
+		}
+		return ""
+	}
+	return ""
+}
+
 func (x *ConfigurePluginResponse) SetBackends(v map[string]*ConfigurePluginResponse_BackendDescription) {
 	x.xxx_hidden_Backends = v
 }
 
+func (x *ConfigurePluginResponse) SetFrontends(v map[string]*ConfigurePluginResponse_FrontendDescription) {
+	x.xxx_hidden_Frontends = v
+}
+
+func (x *ConfigurePluginResponse) SetVersion(v string) {
+	x.xxx_hidden_Version = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *ConfigurePluginResponse) SetBuildDigest(v string) {
+	x.xxx_hidden_BuildDigest = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *ConfigurePluginResponse) HasVersion() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ConfigurePluginResponse) HasBuildDigest() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *ConfigurePluginResponse) ClearVersion() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Version = nil
+}
+
+func (x *ConfigurePluginResponse) ClearBuildDigest() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_BuildDigest = nil
+}
+
 type ConfigurePluginResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Backends map[string]*ConfigurePluginResponse_BackendDescription
+	Backends  map[string]*ConfigurePluginResponse_BackendDescription
+	Frontends map[string]*ConfigurePluginResponse_FrontendDescription
+	// The version of the plugin's module, such as v1.2.3, or (devel) for local builds.
+	Version *string
+	// A digest identifying local builds of the plugin, which changes whenever its code does.
+	// Empty for released versions, which are identified by their version alone.
+	BuildDigest *string
 }
 
 func (b0 ConfigurePluginResponse_builder) Build() *ConfigurePluginResponse {
@@ -118,6 +244,538 @@ func (b0 ConfigurePluginResponse_builder) Build() *ConfigurePluginResponse {
 	b, x := &b0, m0
 	_, _ = b, x
 	x.xxx_hidden_Backends = b.Backends
+	x.xxx_hidden_Frontends = b.Frontends
+	if b.Version != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_Version = b.Version
+	}
+	if b.BuildDigest != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_BuildDigest = b.BuildDigest
+	}
+	return m0
+}
+
+type TaskDescription struct {
+	state                   protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Id           *string                `protobuf:"bytes,1,opt,name=id"`
+	xxx_hidden_Backend      *string                `protobuf:"bytes,2,opt,name=backend"`
+	xxx_hidden_Inputs       []string               `protobuf:"bytes,3,rep,name=inputs"`
+	xxx_hidden_Parameters   *structpb.Struct       `protobuf:"bytes,4,opt,name=parameters"`
+	xxx_hidden_Dependencies []string               `protobuf:"bytes,5,rep,name=dependencies"`
+	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
+	XXX_presence            [1]uint32
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *TaskDescription) Reset() {
+	*x = TaskDescription{}
+	mi := &file_bonk_v0_plugin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskDescription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskDescription) ProtoMessage() {}
+
+func (x *TaskDescription) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_plugin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TaskDescription) GetId() string {
+	if x != nil {
+		if x.xxx_hidden_Id != nil {
+			return *x.xxx_hidden_Id
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TaskDescription) GetBackend() string {
+	if x != nil {
+		if x.xxx_hidden_Backend != nil {
+			return *x.xxx_hidden_Backend
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TaskDescription) GetInputs() []string {
+	if x != nil {
+		return x.xxx_hidden_Inputs
+	}
+	return nil
+}
+
+func (x *TaskDescription) GetParameters() *structpb.Struct {
+	if x != nil {
+		return x.xxx_hidden_Parameters
+	}
+	return nil
+}
+
+func (x *TaskDescription) GetDependencies() []string {
+	if x != nil {
+		return x.xxx_hidden_Dependencies
+	}
+	return nil
+}
+
+func (x *TaskDescription) SetId(v string) {
+	x.xxx_hidden_Id = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *TaskDescription) SetBackend(v string) {
+	x.xxx_hidden_Backend = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+}
+
+func (x *TaskDescription) SetInputs(v []string) {
+	x.xxx_hidden_Inputs = v
+}
+
+func (x *TaskDescription) SetParameters(v *structpb.Struct) {
+	x.xxx_hidden_Parameters = v
+}
+
+func (x *TaskDescription) SetDependencies(v []string) {
+	x.xxx_hidden_Dependencies = v
+}
+
+func (x *TaskDescription) HasId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *TaskDescription) HasBackend() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *TaskDescription) HasParameters() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Parameters != nil
+}
+
+func (x *TaskDescription) ClearId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Id = nil
+}
+
+func (x *TaskDescription) ClearBackend() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Backend = nil
+}
+
+func (x *TaskDescription) ClearParameters() {
+	x.xxx_hidden_Parameters = nil
+}
+
+type TaskDescription_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Id           *string
+	Backend      *string
+	Inputs       []string
+	Parameters   *structpb.Struct
+	Dependencies []string
+}
+
+func (b0 TaskDescription_builder) Build() *TaskDescription {
+	m0 := &TaskDescription{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Id != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_Id = b.Id
+	}
+	if b.Backend != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		x.xxx_hidden_Backend = b.Backend
+	}
+	x.xxx_hidden_Inputs = b.Inputs
+	x.xxx_hidden_Parameters = b.Parameters
+	x.xxx_hidden_Dependencies = b.Dependencies
+	return m0
+}
+
+type GenerateTasksRequest struct {
+	state                    protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Frontend      *string                `protobuf:"bytes,1,opt,name=frontend"`
+	xxx_hidden_Configuration *structpb.Struct       `protobuf:"bytes,2,opt,name=configuration"`
+	XXX_raceDetectHookData   protoimpl.RaceDetectHookData
+	XXX_presence             [1]uint32
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *GenerateTasksRequest) Reset() {
+	*x = GenerateTasksRequest{}
+	mi := &file_bonk_v0_plugin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateTasksRequest) ProtoMessage() {}
+
+func (x *GenerateTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_plugin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GenerateTasksRequest) GetFrontend() string {
+	if x != nil {
+		if x.xxx_hidden_Frontend != nil {
+			return *x.xxx_hidden_Frontend
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *GenerateTasksRequest) GetConfiguration() *structpb.Struct {
+	if x != nil {
+		return x.xxx_hidden_Configuration
+	}
+	return nil
+}
+
+func (x *GenerateTasksRequest) SetFrontend(v string) {
+	x.xxx_hidden_Frontend = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *GenerateTasksRequest) SetConfiguration(v *structpb.Struct) {
+	x.xxx_hidden_Configuration = v
+}
+
+func (x *GenerateTasksRequest) HasFrontend() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *GenerateTasksRequest) HasConfiguration() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Configuration != nil
+}
+
+func (x *GenerateTasksRequest) ClearFrontend() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Frontend = nil
+}
+
+func (x *GenerateTasksRequest) ClearConfiguration() {
+	x.xxx_hidden_Configuration = nil
+}
+
+type GenerateTasksRequest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Frontend      *string
+	Configuration *structpb.Struct
+}
+
+func (b0 GenerateTasksRequest_builder) Build() *GenerateTasksRequest {
+	m0 := &GenerateTasksRequest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Frontend != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Frontend = b.Frontend
+	}
+	x.xxx_hidden_Configuration = b.Configuration
+	return m0
+}
+
+type GenerateTasksResponse struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Tasks *[]*TaskDescription    `protobuf:"bytes,1,rep,name=tasks"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GenerateTasksResponse) Reset() {
+	*x = GenerateTasksResponse{}
+	mi := &file_bonk_v0_plugin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateTasksResponse) ProtoMessage() {}
+
+func (x *GenerateTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_plugin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *GenerateTasksResponse) GetTasks() []*TaskDescription {
+	if x != nil {
+		if x.xxx_hidden_Tasks != nil {
+			return *x.xxx_hidden_Tasks
+		}
+	}
+	return nil
+}
+
+func (x *GenerateTasksResponse) SetTasks(v []*TaskDescription) {
+	x.xxx_hidden_Tasks = &v
+}
+
+type GenerateTasksResponse_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Tasks []*TaskDescription
+}
+
+func (b0 GenerateTasksResponse_builder) Build() *GenerateTasksResponse {
+	m0 := &GenerateTasksResponse{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Tasks = &b.Tasks
+	return m0
+}
+
+type Diagnostic struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Severity    Diagnostic_Severity    `protobuf:"varint,1,opt,name=severity,enum=bonk.v0.Diagnostic_Severity"`
+	xxx_hidden_Message     *string                `protobuf:"bytes,2,opt,name=message"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Diagnostic) Reset() {
+	*x = Diagnostic{}
+	mi := &file_bonk_v0_plugin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Diagnostic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Diagnostic) ProtoMessage() {}
+
+func (x *Diagnostic) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_plugin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Diagnostic) GetSeverity() Diagnostic_Severity {
+	if x != nil {
+		if protoimpl.X.Present(&(x.XXX_presence[0]), 0) {
+			return x.xxx_hidden_Severity
+		}
+	}
+	return Diagnostic_SEVERITY_UNSPECIFIED
+}
+
+func (x *Diagnostic) GetMessage() string {
+	if x != nil {
+		if x.xxx_hidden_Message != nil {
+			return *x.xxx_hidden_Message
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Diagnostic) SetSeverity(v Diagnostic_Severity) {
+	x.xxx_hidden_Severity = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *Diagnostic) SetMessage(v string) {
+	x.xxx_hidden_Message = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *Diagnostic) HasSeverity() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *Diagnostic) HasMessage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *Diagnostic) ClearSeverity() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Severity = Diagnostic_SEVERITY_UNSPECIFIED
+}
+
+func (x *Diagnostic) ClearMessage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Message = nil
+}
+
+type Diagnostic_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Severity *Diagnostic_Severity
+	Message  *string
+}
+
+func (b0 Diagnostic_builder) Build() *Diagnostic {
+	m0 := &Diagnostic{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Severity != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_Severity = *b.Severity
+	}
+	if b.Message != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Message = b.Message
+	}
+	return m0
+}
+
+type TaskResult struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Outputs     []string               `protobuf:"bytes,1,rep,name=outputs"`
+	xxx_hidden_Diagnostics *[]*Diagnostic         `protobuf:"bytes,2,rep,name=diagnostics"`
+	xxx_hidden_Values      map[string]string      `protobuf:"bytes,3,rep,name=values" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *TaskResult) Reset() {
+	*x = TaskResult{}
+	mi := &file_bonk_v0_plugin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskResult) ProtoMessage() {}
+
+func (x *TaskResult) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_plugin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TaskResult) GetOutputs() []string {
+	if x != nil {
+		return x.xxx_hidden_Outputs
+	}
+	return nil
+}
+
+func (x *TaskResult) GetDiagnostics() []*Diagnostic {
+	if x != nil {
+		if x.xxx_hidden_Diagnostics != nil {
+			return *x.xxx_hidden_Diagnostics
+		}
+	}
+	return nil
+}
+
+func (x *TaskResult) GetValues() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Values
+	}
+	return nil
+}
+
+func (x *TaskResult) SetOutputs(v []string) {
+	x.xxx_hidden_Outputs = v
+}
+
+func (x *TaskResult) SetDiagnostics(v []*Diagnostic) {
+	x.xxx_hidden_Diagnostics = &v
+}
+
+func (x *TaskResult) SetValues(v map[string]string) {
+	x.xxx_hidden_Values = v
+}
+
+type TaskResult_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Outputs     []string
+	Diagnostics []*Diagnostic
+	Values      map[string]string
+}
+
+func (b0 TaskResult_builder) Build() *TaskResult {
+	m0 := &TaskResult{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Outputs = b.Outputs
+	x.xxx_hidden_Diagnostics = &b.Diagnostics
+	x.xxx_hidden_Values = b.Values
 	return m0
 }
 
@@ -127,6 +785,7 @@ type PerformTaskRequest struct {
 	xxx_hidden_Inputs       []string               `protobuf:"bytes,2,rep,name=inputs"`
 	xxx_hidden_Parameters   *structpb.Struct       `protobuf:"bytes,3,opt,name=parameters"`
 	xxx_hidden_OutDirectory *string                `protobuf:"bytes,4,opt,name=out_directory,json=outDirectory"`
+	xxx_hidden_Dependencies map[string]*TaskResult `protobuf:"bytes,5,rep,name=dependencies" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	XXX_raceDetectHookData  protoimpl.RaceDetectHookData
 	XXX_presence            [1]uint32
 	unknownFields           protoimpl.UnknownFields
@@ -135,7 +794,7 @@ type PerformTaskRequest struct {
 
 func (x *PerformTaskRequest) Reset() {
 	*x = PerformTaskRequest{}
-	mi := &file_bonk_v0_plugin_proto_msgTypes[2]
+	mi := &file_bonk_v0_plugin_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -147,7 +806,7 @@ func (x *PerformTaskRequest) String() string {
 func (*PerformTaskRequest) ProtoMessage() {}
 
 func (x *PerformTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bonk_v0_plugin_proto_msgTypes[2]
+	mi := &file_bonk_v0_plugin_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -192,9 +851,16 @@ func (x *PerformTaskRequest) GetOutDirectory() string {
 	return ""
 }
 
+func (x *PerformTaskRequest) GetDependencies() map[string]*TaskResult {
+	if x != nil {
+		return x.xxx_hidden_Dependencies
+	}
+	return nil
+}
+
 func (x *PerformTaskRequest) SetBackend(v string) {
 	x.xxx_hidden_Backend = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
 }
 
 func (x *PerformTaskRequest) SetInputs(v []string) {
@@ -207,7 +873,11 @@ func (x *PerformTaskRequest) SetParameters(v *structpb.Struct) {
 
 func (x *PerformTaskRequest) SetOutDirectory(v string) {
 	x.xxx_hidden_OutDirectory = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+}
+
+func (x *PerformTaskRequest) SetDependencies(v map[string]*TaskResult) {
+	x.xxx_hidden_Dependencies = v
 }
 
 func (x *PerformTaskRequest) HasBackend() bool {
@@ -252,6 +922,7 @@ type PerformTaskRequest_builder struct {
 	Inputs       []string
 	Parameters   *structpb.Struct
 	OutDirectory *string
+	Dependencies map[string]*TaskResult
 }
 
 func (b0 PerformTaskRequest_builder) Build() *PerformTaskRequest {
@@ -259,27 +930,29 @@ func (b0 PerformTaskRequest_builder) Build() *PerformTaskRequest {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Backend != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
 		x.xxx_hidden_Backend = b.Backend
 	}
 	x.xxx_hidden_Inputs = b.Inputs
 	x.xxx_hidden_Parameters = b.Parameters
 	if b.OutDirectory != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
 		x.xxx_hidden_OutDirectory = b.OutDirectory
 	}
+	x.xxx_hidden_Dependencies = b.Dependencies
 	return m0
 }
 
 type PerformTaskResponse struct {
-	state         protoimpl.MessageState `protogen:"opaque.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Result *TaskResult            `protobuf:"bytes,1,opt,name=result"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *PerformTaskResponse) Reset() {
 	*x = PerformTaskResponse{}
-	mi := &file_bonk_v0_plugin_proto_msgTypes[3]
+	mi := &file_bonk_v0_plugin_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -291,7 +964,7 @@ func (x *PerformTaskResponse) String() string {
 func (*PerformTaskResponse) ProtoMessage() {}
 
 func (x *PerformTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bonk_v0_plugin_proto_msgTypes[3]
+	mi := &file_bonk_v0_plugin_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -302,15 +975,39 @@ func (x *PerformTaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
+func (x *PerformTaskResponse) GetResult() *TaskResult {
+	if x != nil {
+		return x.xxx_hidden_Result
+	}
+	return nil
+}
+
+func (x *PerformTaskResponse) SetResult(v *TaskResult) {
+	x.xxx_hidden_Result = v
+}
+
+func (x *PerformTaskResponse) HasResult() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Result != nil
+}
+
+func (x *PerformTaskResponse) ClearResult() {
+	x.xxx_hidden_Result = nil
+}
+
 type PerformTaskResponse_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
+	Result *TaskResult
 }
 
 func (b0 PerformTaskResponse_builder) Build() *PerformTaskResponse {
 	m0 := &PerformTaskResponse{}
 	b, x := &b0, m0
 	_, _ = b, x
+	x.xxx_hidden_Result = b.Result
 	return m0
 }
 
@@ -323,7 +1020,7 @@ type ConfigurePluginResponse_BackendDescription struct {
 
 func (x *ConfigurePluginResponse_BackendDescription) Reset() {
 	*x = ConfigurePluginResponse_BackendDescription{}
-	mi := &file_bonk_v0_plugin_proto_msgTypes[4]
+	mi := &file_bonk_v0_plugin_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -335,7 +1032,7 @@ func (x *ConfigurePluginResponse_BackendDescription) String() string {
 func (*ConfigurePluginResponse_BackendDescription) ProtoMessage() {}
 
 func (x *ConfigurePluginResponse_BackendDescription) ProtoReflect() protoreflect.Message {
-	mi := &file_bonk_v0_plugin_proto_msgTypes[4]
+	mi := &file_bonk_v0_plugin_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -371,55 +1068,166 @@ func (b0 ConfigurePluginResponse_BackendDescription_builder) Build() *ConfigureP
 	return m0
 }
 
+type ConfigurePluginResponse_FrontendDescription struct {
+	state         protoimpl.MessageState `protogen:"opaque.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigurePluginResponse_FrontendDescription) Reset() {
+	*x = ConfigurePluginResponse_FrontendDescription{}
+	mi := &file_bonk_v0_plugin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigurePluginResponse_FrontendDescription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigurePluginResponse_FrontendDescription) ProtoMessage() {}
+
+func (x *ConfigurePluginResponse_FrontendDescription) ProtoReflect() protoreflect.Message {
+	mi := &file_bonk_v0_plugin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+type ConfigurePluginResponse_FrontendDescription_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+}
+
+func (b0 ConfigurePluginResponse_FrontendDescription_builder) Build() *ConfigurePluginResponse_FrontendDescription {
+	m0 := &ConfigurePluginResponse_FrontendDescription{}
+	b, x := &b0, m0
+	_, _ = b, x
+	return m0
+}
+
 var File_bonk_v0_plugin_proto protoreflect.FileDescriptor
 
 const file_bonk_v0_plugin_proto_rawDesc = "" +
 	"\n" +
 	"\x14bonk/v0/plugin.proto\x12\abonk.v0\x1a\x1cgoogle/protobuf/struct.proto\"\x18\n" +
-	"\x16ConfigurePluginRequest\"\x87\x02\n" +
+	"\x16ConfigurePluginRequest\"\x9e\x04\n" +
 	"\x17ConfigurePluginResponse\x12J\n" +
-	"\bbackends\x18\x01 \x03(\v2..bonk.v0.ConfigurePluginResponse.BackendsEntryR\bbackends\x1a.\n" +
+	"\bbackends\x18\x01 \x03(\v2..bonk.v0.ConfigurePluginResponse.BackendsEntryR\bbackends\x12M\n" +
+	"\tfrontends\x18\x02 \x03(\v2/.bonk.v0.ConfigurePluginResponse.FrontendsEntryR\tfrontends\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12!\n" +
+	"\fbuild_digest\x18\x04 \x01(\tR\vbuildDigest\x1a.\n" +
 	"\x12BackendDescription\x12\x18\n" +
-	"\aoutputs\x18\x01 \x03(\tR\aoutputs\x1ap\n" +
+	"\aoutputs\x18\x01 \x03(\tR\aoutputs\x1a\x15\n" +
+	"\x13FrontendDescription\x1ap\n" +
 	"\rBackendsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12I\n" +
-	"\x05value\x18\x02 \x01(\v23.bonk.v0.ConfigurePluginResponse.BackendDescriptionR\x05value:\x028\x01\"\xa4\x01\n" +
+	"\x05value\x18\x02 \x01(\v23.bonk.v0.ConfigurePluginResponse.BackendDescriptionR\x05value:\x028\x01\x1ar\n" +
+	"\x0eFrontendsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12J\n" +
+	"\x05value\x18\x02 \x01(\v24.bonk.v0.ConfigurePluginResponse.FrontendDescriptionR\x05value:\x028\x01\"\xb0\x01\n" +
+	"\x0fTaskDescription\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\abackend\x18\x02 \x01(\tR\abackend\x12\x16\n" +
+	"\x06inputs\x18\x03 \x03(\tR\x06inputs\x127\n" +
+	"\n" +
+	"parameters\x18\x04 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"parameters\x12\"\n" +
+	"\fdependencies\x18\x05 \x03(\tR\fdependencies\"q\n" +
+	"\x14GenerateTasksRequest\x12\x1a\n" +
+	"\bfrontend\x18\x01 \x01(\tR\bfrontend\x12=\n" +
+	"\rconfiguration\x18\x02 \x01(\v2\x17.google.protobuf.StructR\rconfiguration\"G\n" +
+	"\x15GenerateTasksResponse\x12.\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x18.bonk.v0.TaskDescriptionR\x05tasks\"\xd7\x01\n" +
+	"\n" +
+	"Diagnostic\x128\n" +
+	"\bseverity\x18\x01 \x01(\x0e2\x1c.bonk.v0.Diagnostic.SeverityR\bseverity\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"u\n" +
+	"\bSeverity\x12\x18\n" +
+	"\x14SEVERITY_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eSEVERITY_DEBUG\x10\x01\x12\x11\n" +
+	"\rSEVERITY_INFO\x10\x02\x12\x14\n" +
+	"\x10SEVERITY_WARNING\x10\x03\x12\x12\n" +
+	"\x0eSEVERITY_ERROR\x10\x04\"\xd1\x01\n" +
+	"\n" +
+	"TaskResult\x12\x18\n" +
+	"\aoutputs\x18\x01 \x03(\tR\aoutputs\x125\n" +
+	"\vdiagnostics\x18\x02 \x03(\v2\x13.bonk.v0.DiagnosticR\vdiagnostics\x127\n" +
+	"\x06values\x18\x03 \x03(\v2\x1f.bonk.v0.TaskResult.ValuesEntryR\x06values\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xcd\x02\n" +
 	"\x12PerformTaskRequest\x12\x18\n" +
 	"\abackend\x18\x01 \x01(\tR\abackend\x12\x16\n" +
 	"\x06inputs\x18\x02 \x03(\tR\x06inputs\x127\n" +
 	"\n" +
 	"parameters\x18\x03 \x01(\v2\x17.google.protobuf.StructR\n" +
 	"parameters\x12#\n" +
-	"\rout_directory\x18\x04 \x01(\tR\foutDirectory\"\x15\n" +
-	"\x13PerformTaskResponse2\xb3\x01\n" +
+	"\rout_directory\x18\x04 \x01(\tR\foutDirectory\x12Q\n" +
+	"\fdependencies\x18\x05 \x03(\v2-.bonk.v0.PerformTaskRequest.DependenciesEntryR\fdependencies\x1aT\n" +
+	"\x11DependenciesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12)\n" +
+	"\x05value\x18\x02 \x01(\v2\x13.bonk.v0.TaskResultR\x05value:\x028\x01\"B\n" +
+	"\x13PerformTaskResponse\x12+\n" +
+	"\x06result\x18\x01 \x01(\v2\x13.bonk.v0.TaskResultR\x06result2\x83\x02\n" +
 	"\x11BonkPluginService\x12T\n" +
-	"\x0fConfigurePlugin\x12\x1f.bonk.v0.ConfigurePluginRequest\x1a .bonk.v0.ConfigurePluginResponse\x12H\n" +
+	"\x0fConfigurePlugin\x12\x1f.bonk.v0.ConfigurePluginRequest\x1a .bonk.v0.ConfigurePluginResponse\x12N\n" +
+	"\rGenerateTasks\x12\x1d.bonk.v0.GenerateTasksRequest\x1a\x1e.bonk.v0.GenerateTasksResponse\x12H\n" +
 	"\vPerformTask\x12\x1b.bonk.v0.PerformTaskRequest\x1a\x1c.bonk.v0.PerformTaskResponseB\x80\x01\n" +
 	"\vcom.bonk.v0B\vPluginProtoP\x01Z\"go.bonk.build/api/go/proto/bonk/v0\xa2\x02\x03BVX\xaa\x02\aBonk.V0\xca\x02\aBonk\\V0\xe2\x02\x13Bonk\\V0\\GPBMetadata\xea\x02\bBonk::V0\x92\x03\x02\b\x01b\beditionsp\xe8\a"
 
-var file_bonk_v0_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_bonk_v0_plugin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_bonk_v0_plugin_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_bonk_v0_plugin_proto_goTypes = []any{
-	(*ConfigurePluginRequest)(nil),                     // 0: bonk.v0.ConfigurePluginRequest
-	(*ConfigurePluginResponse)(nil),                    // 1: bonk.v0.ConfigurePluginResponse
-	(*PerformTaskRequest)(nil),                         // 2: bonk.v0.PerformTaskRequest
-	(*PerformTaskResponse)(nil),                        // 3: bonk.v0.PerformTaskResponse
-	(*ConfigurePluginResponse_BackendDescription)(nil), // 4: bonk.v0.ConfigurePluginResponse.BackendDescription
-	nil,                     // 5: bonk.v0.ConfigurePluginResponse.BackendsEntry
-	(*structpb.Struct)(nil), // 6: google.protobuf.Struct
+	(Diagnostic_Severity)(0),                            // 0: bonk.v0.Diagnostic.Severity
+	(*ConfigurePluginRequest)(nil),                      // 1: bonk.v0.ConfigurePluginRequest
+	(*ConfigurePluginResponse)(nil),                     // 2: bonk.v0.ConfigurePluginResponse
+	(*TaskDescription)(nil),                             // 3: bonk.v0.TaskDescription
+	(*GenerateTasksRequest)(nil),                        // 4: bonk.v0.GenerateTasksRequest
+	(*GenerateTasksResponse)(nil),                       // 5: bonk.v0.GenerateTasksResponse
+	(*Diagnostic)(nil),                                  // 6: bonk.v0.Diagnostic
+	(*TaskResult)(nil),                                  // 7: bonk.v0.TaskResult
+	(*PerformTaskRequest)(nil),                          // 8: bonk.v0.PerformTaskRequest
+	(*PerformTaskResponse)(nil),                         // 9: bonk.v0.PerformTaskResponse
+	(*ConfigurePluginResponse_BackendDescription)(nil),  // 10: bonk.v0.ConfigurePluginResponse.BackendDescription
+	(*ConfigurePluginResponse_FrontendDescription)(nil), // 11: bonk.v0.ConfigurePluginResponse.FrontendDescription
+	nil,                     // 12: bonk.v0.ConfigurePluginResponse.BackendsEntry
+	nil,                     // 13: bonk.v0.ConfigurePluginResponse.FrontendsEntry
+	nil,                     // 14: bonk.v0.TaskResult.ValuesEntry
+	nil,                     // 15: bonk.v0.PerformTaskRequest.DependenciesEntry
+	(*structpb.Struct)(nil), // 16: google.protobuf.Struct
 }
 var file_bonk_v0_plugin_proto_depIdxs = []int32{
-	5, // 0: bonk.v0.ConfigurePluginResponse.backends:type_name -> bonk.v0.ConfigurePluginResponse.BackendsEntry
-	6, // 1: bonk.v0.PerformTaskRequest.parameters:type_name -> google.protobuf.Struct
-	4, // 2: bonk.v0.ConfigurePluginResponse.BackendsEntry.value:type_name -> bonk.v0.ConfigurePluginResponse.BackendDescription
-	0, // 3: bonk.v0.BonkPluginService.ConfigurePlugin:input_type -> bonk.v0.ConfigurePluginRequest
-	2, // 4: bonk.v0.BonkPluginService.PerformTask:input_type -> bonk.v0.PerformTaskRequest
-	1, // 5: bonk.v0.BonkPluginService.ConfigurePlugin:output_type -> bonk.v0.ConfigurePluginResponse
-	3, // 6: bonk.v0.BonkPluginService.PerformTask:output_type -> bonk.v0.PerformTaskResponse
-	5, // [5:7] is the sub-list for method output_type
-	3, // [3:5] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	12, // 0: bonk.v0.ConfigurePluginResponse.backends:type_name -> bonk.v0.ConfigurePluginResponse.BackendsEntry
+	13, // 1: bonk.v0.ConfigurePluginResponse.frontends:type_name -> bonk.v0.ConfigurePluginResponse.FrontendsEntry
+	16, // 2: bonk.v0.TaskDescription.parameters:type_name -> google.protobuf.Struct
+	16, // 3: bonk.v0.GenerateTasksRequest.configuration:type_name -> google.protobuf.Struct
+	3,  // 4: bonk.v0.GenerateTasksResponse.tasks:type_name -> bonk.v0.TaskDescription
+	0,  // 5: bonk.v0.Diagnostic.severity:type_name -> bonk.v0.Diagnostic.Severity
+	6,  // 6: bonk.v0.TaskResult.diagnostics:type_name -> bonk.v0.Diagnostic
+	14, // 7: bonk.v0.TaskResult.values:type_name -> bonk.v0.TaskResult.ValuesEntry
+	16, // 8: bonk.v0.PerformTaskRequest.parameters:type_name -> google.protobuf.Struct
+	15, // 9: bonk.v0.PerformTaskRequest.dependencies:type_name -> bonk.v0.PerformTaskRequest.DependenciesEntry
+	7,  // 10: bonk.v0.PerformTaskResponse.result:type_name -> bonk.v0.TaskResult
+	10, // 11: bonk.v0.ConfigurePluginResponse.BackendsEntry.value:type_name -> bonk.v0.ConfigurePluginResponse.BackendDescription
+	11, // 12: bonk.v0.ConfigurePluginResponse.FrontendsEntry.value:type_name -> bonk.v0.ConfigurePluginResponse.FrontendDescription
+	7,  // 13: bonk.v0.PerformTaskRequest.DependenciesEntry.value:type_name -> bonk.v0.TaskResult
+	1,  // 14: bonk.v0.BonkPluginService.ConfigurePlugin:input_type -> bonk.v0.ConfigurePluginRequest
+	4,  // 15: bonk.v0.BonkPluginService.GenerateTasks:input_type -> bonk.v0.GenerateTasksRequest
+	8,  // 16: bonk.v0.BonkPluginService.PerformTask:input_type -> bonk.v0.PerformTaskRequest
+	2,  // 17: bonk.v0.BonkPluginService.ConfigurePlugin:output_type -> bonk.v0.ConfigurePluginResponse
+	5,  // 18: bonk.v0.BonkPluginService.GenerateTasks:output_type -> bonk.v0.GenerateTasksResponse
+	9,  // 19: bonk.v0.BonkPluginService.PerformTask:output_type -> bonk.v0.PerformTaskResponse
+	17, // [17:20] is the sub-list for method output_type
+	14, // [14:17] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_bonk_v0_plugin_proto_init() }
@@ -432,13 +1240,14 @@ func file_bonk_v0_plugin_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bonk_v0_plugin_proto_rawDesc), len(file_bonk_v0_plugin_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      1,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_bonk_v0_plugin_proto_goTypes,
 		DependencyIndexes: file_bonk_v0_plugin_proto_depIdxs,
+		EnumInfos:         file_bonk_v0_plugin_proto_enumTypes,
 		MessageInfos:      file_bonk_v0_plugin_proto_msgTypes,
 	}.Build()
 	File_bonk_v0_plugin_proto = out.File
@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	BonkPluginService_ConfigurePlugin_FullMethodName = "/bonk.v0.BonkPluginService/ConfigurePlugin"
+	BonkPluginService_GenerateTasks_FullMethodName   = "/bonk.v0.BonkPluginService/GenerateTasks"
 	BonkPluginService_PerformTask_FullMethodName     = "/bonk.v0.BonkPluginService/PerformTask"
 )
 
@@ -28,6 +29,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type BonkPluginServiceClient interface {
 	ConfigurePlugin(ctx context.Context, in *ConfigurePluginRequest, opts ...grpc.CallOption) (*ConfigurePluginResponse, error)
+	GenerateTasks(ctx context.Context, in *GenerateTasksRequest, opts ...grpc.CallOption) (*GenerateTasksResponse, error)
 	PerformTask(ctx context.Context, in *PerformTaskRequest, opts ...grpc.CallOption) (*PerformTaskResponse, error)
 }
 
@@ -49,6 +51,16 @@ func (c *bonkPluginServiceClient) ConfigurePlugin(ctx context.Context, in *Confi
 	return out, nil
 }
 
+func (c *bonkPluginServiceClient) GenerateTasks(ctx context.Context, in *GenerateTasksRequest, opts ...grpc.CallOption) (*GenerateTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateTasksResponse)
+	err := c.cc.Invoke(ctx, BonkPluginService_GenerateTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bonkPluginServiceClient) PerformTask(ctx context.Context, in *PerformTaskRequest, opts ...grpc.CallOption) (*PerformTaskResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PerformTaskResponse)
@@ -64,6 +76,7 @@ func (c *bonkPluginServiceClient) PerformTask(ctx context.Context, in *PerformTa
 // for forward compatibility.
 type BonkPluginServiceServer interface {
 	ConfigurePlugin(context.Context, *ConfigurePluginRequest) (*ConfigurePluginResponse, error)
+	GenerateTasks(context.Context, *GenerateTasksRequest) (*GenerateTasksResponse, error)
 	PerformTask(context.Context, *PerformTaskRequest) (*PerformTaskResponse, error)
 	mustEmbedUnimplementedBonkPluginServiceServer()
 }
@@ -78,6 +91,9 @@ type UnimplementedBonkPluginServiceServer struct{}
 func (UnimplementedBonkPluginServiceServer) ConfigurePlugin(context.Context, *ConfigurePluginRequest) (*ConfigurePluginResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ConfigurePlugin not implemented")
 }
+func (UnimplementedBonkPluginServiceServer) GenerateTasks(context.Context, *GenerateTasksRequest) (*GenerateTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateTasks not implemented")
+}
 func (UnimplementedBonkPluginServiceServer) PerformTask(context.Context, *PerformTaskRequest) (*PerformTaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PerformTask not implemented")
 }
@@ -120,6 +136,24 @@ func _BonkPluginService_ConfigurePlugin_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BonkPluginService_GenerateTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BonkPluginServiceServer).GenerateTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BonkPluginService_GenerateTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BonkPluginServiceServer).GenerateTasks(ctx, req.(*GenerateTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BonkPluginService_PerformTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PerformTaskRequest)
 	if err := dec(in); err != nil {
@@ -149,6 +183,10 @@ var BonkPluginService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ConfigurePlugin",
 			Handler:    _BonkPluginService_ConfigurePlugin_Handler,
 		},
+		{
+			MethodName: "GenerateTasks",
+			Handler:    _BonkPluginService_GenerateTasks_Handler,
+		},
 		{
 			MethodName: "PerformTask",
 			Handler:    _BonkPluginService_PerformTask_Handler,
@@ -0,0 +1,92 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package bonk // import "go.bonk.build/api/go"
+
+import (
+	"log/slog"
+
+	bonkv0 "go.bonk.build/api/go/proto/bonk/v0"
+)
+
+// A message reported by a backend while performing a task.
+type Diagnostic struct {
+	Severity slog.Level
+	Message  string
+}
+
+// The outcome of a task, as reported by its backend.
+type TaskResult struct {
+	// The files produced by the task, relative to its output directory.
+	Outputs     []string
+	Diagnostics []Diagnostic
+	Values      map[string]string
+}
+
+// Converts a diagnostic severity into the equivalent log level.
+func SeverityLevel(severity bonkv0.Diagnostic_Severity) slog.Level {
+	switch severity {
+	case bonkv0.Diagnostic_SEVERITY_DEBUG:
+		return slog.LevelDebug
+	case bonkv0.Diagnostic_SEVERITY_WARNING:
+		return slog.LevelWarn
+	case bonkv0.Diagnostic_SEVERITY_ERROR:
+		return slog.LevelError
+	case bonkv0.Diagnostic_SEVERITY_INFO, bonkv0.Diagnostic_SEVERITY_UNSPECIFIED:
+		return slog.LevelInfo
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// Converts a log level into the closest diagnostic severity.
+func LevelSeverity(level slog.Level) bonkv0.Diagnostic_Severity {
+	switch {
+	case level >= slog.LevelError:
+		return bonkv0.Diagnostic_SEVERITY_ERROR
+	case level >= slog.LevelWarn:
+		return bonkv0.Diagnostic_SEVERITY_WARNING
+	case level >= slog.LevelInfo:
+		return bonkv0.Diagnostic_SEVERITY_INFO
+	default:
+		return bonkv0.Diagnostic_SEVERITY_DEBUG
+	}
+}
+
+// Converts the result into its protobuf representation.
+func (result *TaskResult) ToProto() *bonkv0.TaskResult {
+	if result == nil {
+		return bonkv0.TaskResult_builder{}.Build()
+	}
+
+	diagnostics := make([]*bonkv0.Diagnostic, len(result.Diagnostics))
+	for idx, diag := range result.Diagnostics {
+		diagnostics[idx] = bonkv0.Diagnostic_builder{
+			Severity: LevelSeverity(diag.Severity).Enum(),
+			Message:  &diag.Message,
+		}.Build()
+	}
+
+	return bonkv0.TaskResult_builder{
+		Outputs:     result.Outputs,
+		Diagnostics: diagnostics,
+		Values:      result.Values,
+	}.Build()
+}
+
+// Converts a protobuf task result.
+func TaskResultFromProto(result *bonkv0.TaskResult) TaskResult {
+	diagnostics := make([]Diagnostic, len(result.GetDiagnostics()))
+	for idx, diag := range result.GetDiagnostics() {
+		diagnostics[idx] = Diagnostic{
+			Severity: SeverityLevel(diag.GetSeverity()),
+			Message:  diag.GetMessage(),
+		}
+	}
+
+	return TaskResult{
+		Outputs:     result.GetOutputs(),
+		Diagnostics: diagnostics,
+		Values:      result.GetValues(),
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package bonk // import "go.bonk.build/api/go"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"runtime/debug"
+	"sync"
+)
+
+// Identifies the running plugin build, so that bonk can rebuild tasks when a plugin changes.
+// Released builds are identified by their module version alone, while local builds are also
+// identified by a digest of their executable.
+var buildIdentity = sync.OnceValues(func() (string, string) {
+	version := "(unknown)"
+
+	info, ok := debug.ReadBuildInfo()
+	if ok && info.Main.Version != "" {
+		version = info.Main.Version
+	}
+
+	if version != "(devel)" && version != "(unknown)" {
+		return version, ""
+	}
+
+	return version, executableDigest()
+})
+
+// Hashes the running executable. Local builds all report a version of (devel), so the
+// executable is the only reliable record of what the plugin was built from.
+func executableDigest() string {
+	executable, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+
+	file, err := os.Open(executable)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+
+	_, err = io.Copy(hasher, file)
+	if err != nil {
+		return ""
+	}
+
+	return "sha256:" + hex.EncodeToString(hasher.Sum(nil))
+}
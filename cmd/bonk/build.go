@@ -4,70 +4,301 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
-
-	"cuelang.org/go/cue"
-	"cuelang.org/go/cue/cuecontext"
-	"cuelang.org/go/cue/format"
-	"cuelang.org/go/cue/load"
+	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"go.bonk.build/pkg/cache"
+	"go.bonk.build/pkg/plugin"
+	"go.bonk.build/pkg/profile"
+	"go.bonk.build/pkg/project"
+	"go.bonk.build/pkg/scheduler"
 )
 
+var (
+	keepGoing     bool
+	strictOutputs bool
+	noCache       bool
+	dryRun        bool
+	reverseDeps   bool
+	noDaemon      bool
+	profileFile   string
+)
+
+// The options controlling a build, taken from flags or from a build request sent to the daemon.
+type buildOptions struct {
+	keepGoing     bool
+	strictOutputs bool
+	noCache       bool
+	reverseDeps   bool
+	concurrency   uint
+	// The file to write a trace of the build to, or empty to not profile it.
+	profileFile string
+}
+
+// Returns the build options set by flags.
+func flagBuildOptions() buildOptions {
+	return buildOptions{
+		keepGoing:     keepGoing,
+		strictOutputs: strictOutputs,
+		noCache:       noCache,
+		reverseDeps:   reverseDeps,
+		concurrency:   concurrency,
+		profileFile:   profileFile,
+	}
+}
+
 // buildCmd represents the build command.
 var buildCmd = &cobra.Command{
-	Use:   "build [paths...]",
-	Short: "A brief description of your command",
+	Use:   "build [targets...]",
+	Short: "Builds the tasks declared by the project",
+	Long: `Builds the tasks declared by the project.
+
+When targets are given, only the tasks they match and their dependencies are built. Targets
+are glob patterns matched against task ids, such as Kustomize, //Test.*, or *:test:Test.`,
 
-	Args:       cobra.ArbitraryArgs,
-	ArgAliases: []string{"paths"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := flagBuildOptions()
 
-	Run: func(cmd *cobra.Command, args []string) {
-		slog.InfoContext(cmd.Context(), "perforing build")
+		if !dryRun && !noDaemon {
+			conn, err := dialDaemon(cmd.Context())
+			if err != nil {
+				return err
+			}
 
-		config := load.Config{}
+			if conn != nil {
+				defer conn.Close()
+
+				return buildWithDaemon(cmd.Context(), conn, args, opts)
+			}
+		}
+
+		slog.InfoContext(cmd.Context(), "performing build")
+
+		sess, err := openSession(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer sess.Close()
+
+		err = sess.selectTargets(args, opts.reverseDeps)
+		if err != nil {
+			return err
+		}
 
-		// If there's more than 1 arg, use the first as the root
-		if len(args) > 0 {
-			config.Dir = args[0]
-			args[0] = "."
-			slog.DebugContext(cmd.Context(), "using arg[0] as Dir", "dir", config.Dir)
+		if dryRun {
+			return predictBuild(cmd, sess)
 		}
 
-		cuectx := cuecontext.New()
-		insts := load.Instances(args, &config)
-		values, err := cuectx.BuildInstances(insts)
+		err = configureBackends(sess, opts)
 		if err != nil {
-			slog.ErrorContext(cmd.Context(), "failed to build bonk project", "error", err.Error())
+			return err
 		}
 
-		// Unify all of the values into a single source of truth
-		value := cue.Value{}
-		for _, valuePart := range values {
-			value = value.Unify(valuePart)
+		return runBuild(cmd.Context(), sess, opts)
+	},
+}
+
+// Applies the build options to the session's backends.
+func configureBackends(sess *session, opts buildOptions) error {
+	sess.backends.SetStrictOutputs(opts.strictOutputs)
+
+	var buildCache cache.Cache
+
+	if !opts.noCache {
+		var err error
+
+		buildCache, err = sess.cache()
+		if err != nil {
+			return err
 		}
+	}
+
+	sess.backends.SetCache(buildCache)
+
+	return nil
+}
 
-		holos := value.LookupPath(cue.MakePath(cue.Str("holos")))
+// Schedules and executes the session's tasks, writing a profile of the build if requested.
+func runBuild(ctx context.Context, sess *session, opts buildOptions) error {
+	var buildProfile *profile.Profile
+	if opts.profileFile != "" {
+		buildProfile = profile.New()
+	}
 
-		source := holos.Pos()
-		slog.DebugContext(cmd.Context(), "source", "source", source.String())
+	sess.backends.SetProfile(buildProfile)
 
-		syn := holos.Syntax(
-			cue.Final(),
-			cue.Attributes(false),
-		)
+	sched := scheduler.NewScheduler(sess.backends, opts.concurrency)
 
-		str, err := format.Node(syn,
-			format.TabIndent(false),
-			format.Simplify(),
-		)
+	for _, tsk := range sess.tasks {
+		err := sched.AddTask(tsk)
 		if err != nil {
-			slog.ErrorContext(cmd.Context(), "failed to encode value", "error", err.Error())
+			return fmt.Errorf("failed to schedule task %s: %w", tsk.ID.String(), err)
 		}
-		slog.InfoContext(cmd.Context(), string(str))
-	},
+	}
+
+	report, err := sched.Run(ctx, scheduler.RunOptions{
+		KeepGoing: opts.keepGoing,
+		Profile:   buildProfile,
+	})
+
+	saveErr := sess.fileHashes.Save()
+	if saveErr != nil {
+		slog.WarnContext(ctx, "failed to save file hashes", "error", saveErr)
+	}
+
+	if buildProfile != nil {
+		profileErr := writeProfile(buildProfile, opts.profileFile)
+		if profileErr != nil {
+			slog.WarnContext(ctx, "failed to write build profile", "error", profileErr)
+		} else {
+			slog.InfoContext(ctx, "wrote build profile", "file", opts.profileFile)
+		}
+	}
+
+	slog.InfoContext(ctx, "build finished",
+		"succeeded", len(report.Tasks(scheduler.StatusSucceeded)),
+		"failed", len(report.Tasks(scheduler.StatusFailed)),
+		"skipped", len(report.Tasks(scheduler.StatusSkipped)),
+	)
+
+	if err != nil {
+		return fmt.Errorf("build failed: %w", err)
+	}
+
+	return nil
+}
+
+// Writes the profile to profileFile as a Chrome trace.
+func writeProfile(buildProfile *profile.Profile, profileFile string) error {
+	file, err := os.Create(profileFile)
+	if err != nil {
+		return fmt.Errorf("failed to create profile file: %w", err)
+	}
+
+	err = buildProfile.WriteTrace(file)
+	if err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close profile file: %w", err)
+	}
+
+	return nil
+}
+
+// Walks the build graph without executing anything, returning the scheduler along with its
+// predictions of which tasks would execute.
+func runDryRun(
+	ctx context.Context,
+	sess *session,
+) (*scheduler.Scheduler, []scheduler.Prediction, error) {
+	dryRun := scheduler.NewDryRun(sess.fileHashes)
+	sched := scheduler.NewScheduler(dryRun, concurrency)
+
+	for _, tsk := range sess.tasks {
+		err := sched.AddTask(tsk)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to schedule task %s: %w", tsk.ID.String(), err)
+		}
+	}
+
+	_, err := sched.Run(ctx, scheduler.RunOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("dry run failed: %w", err)
+	}
+
+	return sched, dryRun.Predictions(), nil
+}
+
+// Prints which tasks would execute and why, without executing anything.
+func predictBuild(cmd *cobra.Command, sess *session) error {
+	_, predictions, err := runDryRun(cmd.Context(), sess)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	stale := 0
+
+	for _, prediction := range predictions {
+		if len(prediction.Reasons) == 0 {
+			fmt.Fprintf(out, "up to date  %s\n", prediction.Task)
+
+			continue
+		}
+
+		stale++
+
+		fmt.Fprintf(out, "stale       %s\n", prediction.Task)
+
+		for _, reason := range prediction.Reasons {
+			fmt.Fprintf(out, "              - %s\n", reason)
+		}
+	}
+
+	fmt.Fprintf(out, "%d of %d tasks would execute\n", stale, len(sess.tasks))
+
+	return nil
+}
+
+// Collects the plugins required by the project and the config file.
+func requiredPlugins(proj *project.Project) ([]string, error) {
+	projectPlugins, err := proj.Plugins()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project plugins: %w", err)
+	}
+
+	plugins := []string{}
+	versions := make(map[string]string)
+
+	for _, pluginRef := range append(projectPlugins, viper.GetStringSlice("plugins")...) {
+		pluginPath, version := plugin.ParseReference(pluginRef)
+
+		existing, ok := versions[pluginPath]
+		if ok {
+			if existing != version {
+				return nil, fmt.Errorf(
+					"plugin %s is required at conflicting versions %q and %q",
+					pluginPath,
+					existing,
+					version,
+				)
+			}
+
+			continue
+		}
+
+		versions[pluginPath] = version
+		plugins = append(plugins, pluginRef)
+	}
+
+	return plugins, nil
 }
 
 func init() {
+	buildCmd.Flags().
+		BoolVarP(&keepGoing, "keep-going", "k", false, "keep building tasks which don't depend on a failure")
+	buildCmd.Flags().
+		BoolVar(&strictOutputs, "strict-outputs", false, "fail tasks which produce undeclared outputs")
+	buildCmd.Flags().
+		BoolVar(&noCache, "no-cache", false, "don't restore or store task outputs in the build cache")
+	buildCmd.Flags().
+		BoolVar(&reverseDeps, "rdeps", false, "also build every task which depends on a target")
+	buildCmd.Flags().
+		BoolVarP(&dryRun, "dry-run", "n", false, "report which tasks would execute, and why, without executing them")
+	buildCmd.Flags().
+		StringVar(&profileFile, "profile", "", "write a Chrome trace of how long each task took to a file, such as build.json")
+	buildCmd.Flags().
+		BoolVar(&noDaemon, "no-daemon", false, "build in this process, even if a daemon is running")
+
 	rootCmd.AddCommand(buildCmd)
 }
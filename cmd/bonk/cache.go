@@ -0,0 +1,207 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"go.bonk.build/pkg/cache"
+)
+
+var (
+	gcMaxSize    string
+	gcMaxAge     time.Duration
+	serveAddress string
+)
+
+// cacheCmd represents the cache command.
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manages the build cache",
+}
+
+// cacheGCCmd represents the cache gc command.
+var cacheGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Prunes the local build cache by size and age",
+
+	Args: cobra.NoArgs,
+
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		maxSize, err := parseSize(gcMaxSize)
+		if err != nil {
+			return fmt.Errorf("invalid --max-size: %w", err)
+		}
+
+		localCache, err := openLocalCache()
+		if err != nil {
+			return err
+		}
+
+		removed, removedSize, err := localCache.GC(cmd.Context(), maxSize, gcMaxAge)
+
+		slog.InfoContext(cmd.Context(), "pruned build cache",
+			"entries", removed,
+			"bytes", removedSize,
+		)
+
+		if err != nil {
+			return fmt.Errorf("failed to prune build cache: %w", err)
+		}
+
+		return nil
+	},
+}
+
+// cacheServeCmd represents the cache serve command.
+var cacheServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serves a file-backed remote build cache over HTTP",
+
+	Args: cobra.NoArgs,
+
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		storageDir := userCacheDirectory("cache-storage-dir", "remote-cache")
+		if storageDir == "" {
+			return errors.New("no storage directory is configured")
+		}
+
+		handler, err := cache.NewServer(storageDir)
+		if err != nil {
+			return fmt.Errorf("failed to create cache server: %w", err)
+		}
+
+		server := &http.Server{
+			Addr:              serveAddress,
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		go func() {
+			<-cmd.Context().Done()
+
+			_ = server.Shutdown(context.Background())
+		}()
+
+		slog.InfoContext(cmd.Context(), "serving build cache",
+			"address", serveAddress,
+			"storage", storageDir,
+		)
+
+		err = server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("failed to serve build cache: %w", err)
+		}
+
+		return nil
+	},
+}
+
+// Opens the configured build cache: the local cache, backed by the remote cache if one is
+// configured.
+func openCache() (cache.Cache, error) {
+	localCache, err := openLocalCache()
+	if err != nil {
+		return nil, err
+	}
+
+	remoteURL := viper.GetString("remote-cache")
+	if remoteURL == "" {
+		return localCache, nil
+	}
+
+	var remoteCache cache.Cache
+
+	if strings.HasPrefix(remoteURL, "grpc://") || strings.HasPrefix(remoteURL, "grpcs://") {
+		remoteCache, err = cache.DialREAPICache(remoteURL)
+	} else {
+		remoteCache, err = cache.NewHTTPCache(remoteURL)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open remote build cache: %w", err)
+	}
+
+	return cache.NewTieredCache(localCache, remoteCache), nil
+}
+
+// Opens the configured local build cache.
+func openLocalCache() (*cache.LocalCache, error) {
+	cacheDir := userCacheDirectory("cache-dir", "cache")
+	if cacheDir == "" {
+		return nil, errors.New("no cache directory is configured")
+	}
+
+	localCache, err := cache.NewLocalCache(cacheDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open build cache: %w", err)
+	}
+
+	return localCache, nil
+}
+
+// Parses a size in bytes with an optional binary unit suffix, such as 512M or 10G.
+func parseSize(size string) (int64, error) {
+	if size == "" {
+		return 0, nil
+	}
+
+	units := map[string]int64{
+		"K": 1 << 10,
+		"M": 1 << 20,
+		"G": 1 << 30,
+		"T": 1 << 40,
+	}
+
+	multiplier := int64(1)
+	trimmed := strings.TrimSuffix(strings.ToUpper(size), "B")
+
+	if len(trimmed) > 0 {
+		unit, ok := units[trimmed[len(trimmed)-1:]]
+		if ok {
+			multiplier = unit
+			trimmed = trimmed[:len(trimmed)-1]
+		}
+	}
+
+	value, err := strconv.ParseInt(trimmed, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse size %s: %w", size, err)
+	}
+
+	return value * multiplier, nil
+}
+
+func init() {
+	cacheGCCmd.Flags().
+		StringVar(&gcMaxSize, "max-size", "", "the maximum total size of the cache, such as 10G")
+	cacheGCCmd.Flags().
+		DurationVar(&gcMaxAge, "max-age", 0, "remove entries which haven't been used for this long")
+
+	cacheServeCmd.Flags().
+		StringVar(&serveAddress, "address", "localhost:8935", "the address to listen on")
+	cacheServeCmd.Flags().String(
+		"storage-dir",
+		"",
+		"the directory to store entries in (default is $XDG_CACHE_HOME/bonk/remote-cache)",
+	)
+
+	cobra.CheckErr(
+		viper.BindPFlag("cache-storage-dir", cacheServeCmd.Flags().Lookup("storage-dir")),
+	)
+
+	cacheCmd.AddCommand(cacheGCCmd)
+	cacheCmd.AddCommand(cacheServeCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
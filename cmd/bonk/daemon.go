@@ -0,0 +1,435 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	bonk "go.bonk.build/api/go"
+	bonkv0 "go.bonk.build/api/go/proto/bonk/v0"
+)
+
+// How long to wait when checking whether a daemon is listening.
+const daemonDialTimeout = 100 * time.Millisecond
+
+// daemonCmd represents the daemon command.
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Serves builds of the project from a long-running process",
+	Long: `Serves builds of the project from a long-running process, which keeps plugins running
+and input hashes in memory between builds.
+
+While the daemon is running, bonk build in the project directory sends builds to it over a
+Unix socket in the .bonk directory, rather than starting its own plugins. The project is
+reloaded for every build, so changes to its cue files are picked up.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := context.WithCancel(cmd.Context())
+		defer stop()
+
+		return serveDaemon(ctx, stop)
+	},
+}
+
+// daemonStopCmd represents the daemon stop command.
+var daemonStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stops the daemon serving the project",
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn, err := dialDaemon(cmd.Context())
+		if err != nil {
+			return err
+		}
+		if conn == nil {
+			return errors.New("no daemon is running")
+		}
+		defer conn.Close()
+
+		_, err = bonkv0.NewBonkDaemonServiceClient(conn).
+			Shutdown(cmd.Context(), &bonkv0.ShutdownRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to stop daemon: %w", err)
+		}
+
+		return nil
+	},
+}
+
+// Returns the path of the socket the daemon listens on, relative to the project directory.
+func daemonSocket() string {
+	return filepath.Join(".bonk", "daemon.sock")
+}
+
+// Connects to the daemon serving the project, returning nil if none is running.
+func dialDaemon(ctx context.Context) (*grpc.ClientConn, error) {
+	dialer := net.Dialer{Timeout: daemonDialTimeout}
+
+	probe, err := dialer.DialContext(ctx, "unix", daemonSocket())
+	if err != nil {
+		return nil, nil
+	}
+
+	_ = probe.Close()
+
+	conn, err := grpc.NewClient(
+		"unix:"+daemonSocket(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to daemon: %w", err)
+	}
+
+	return conn, nil
+}
+
+// Listens on the daemon socket and serves builds until ctx is done or stop is called.
+func serveDaemon(ctx context.Context, stop func()) error {
+	conn, err := dialDaemon(ctx)
+	if err != nil {
+		return err
+	}
+	if conn != nil {
+		_ = conn.Close()
+
+		return errors.New("a daemon is already running for this project")
+	}
+
+	// Clear out the socket of a daemon which didn't shut down cleanly
+	err = os.Remove(daemonSocket())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove stale daemon socket: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(daemonSocket()), 0o750)
+	if err != nil {
+		return fmt.Errorf("failed to create daemon socket directory: %w", err)
+	}
+
+	// Forward the logs of each build to the client which requested it
+	slog.SetDefault(slog.New(&forwardingHandler{Handler: slog.Default().Handler()}))
+
+	sess, err := openSession(ctx)
+	if err != nil {
+		return err
+	}
+	defer sess.Close()
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "unix", daemonSocket())
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", daemonSocket(), err)
+	}
+
+	server := grpc.NewServer()
+	bonkv0.RegisterBonkDaemonServiceServer(server, &daemonServer{
+		sess:     sess,
+		settings: currentDaemonSettings(),
+		stop:     stop,
+	})
+
+	go func() {
+		<-ctx.Done()
+
+		server.GracefulStop()
+	}()
+
+	slog.InfoContext(ctx, "serving builds", "socket", daemonSocket())
+
+	err = server.Serve(listener)
+	if err != nil {
+		return fmt.Errorf("failed to serve builds: %w", err)
+	}
+
+	return nil
+}
+
+// Sends the build to the daemon, logging the records it forwards.
+func buildWithDaemon(
+	ctx context.Context,
+	conn *grpc.ClientConn,
+	targets []string,
+	opts buildOptions,
+) error {
+	slog.DebugContext(ctx, "sending build to daemon", "socket", daemonSocket())
+
+	// The daemon resolves relative paths against its own working directory
+	profilePath := opts.profileFile
+	if profilePath != "" {
+		var err error
+
+		profilePath, err = filepath.Abs(profilePath)
+		if err != nil {
+			return fmt.Errorf("failed to resolve profile file: %w", err)
+		}
+	}
+
+	settings := currentDaemonSettings()
+	buildConcurrency := uint32(min(opts.concurrency, math.MaxUint32))
+
+	req := bonkv0.BuildRequest_builder{
+		Targets:             targets,
+		KeepGoing:           &opts.keepGoing,
+		StrictOutputs:       &opts.strictOutputs,
+		NoCache:             &opts.noCache,
+		ReverseDependencies: &opts.reverseDeps,
+		Profile:             &profilePath,
+		Concurrency:         &buildConcurrency,
+		RemoteCache:         &settings.remoteCache,
+		CacheDir:            &settings.cacheDir,
+		PluginDir:           &settings.pluginDir,
+	}.Build()
+
+	stream, err := bonkv0.NewBonkDaemonServiceClient(conn).Build(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to send build to daemon: %w", err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return errors.New("daemon closed the build without a result")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to receive build from daemon: %w", err)
+		}
+
+		switch resp.WhichEvent() {
+		case bonkv0.BuildResponse_Log_case:
+			record := resp.GetLog()
+
+			attrs := make([]any, 0, 2*len(record.GetAttributes()))
+			for key, value := range record.GetAttributes() {
+				attrs = append(attrs, key, value)
+			}
+
+			slog.Log(ctx, bonk.SeverityLevel(record.GetSeverity()), record.GetMessage(), attrs...)
+
+		case bonkv0.BuildResponse_Result_case:
+			if resp.GetResult().HasError() {
+				return errors.New(resp.GetResult().GetError())
+			}
+
+			return nil
+
+		case bonkv0.BuildResponse_Event_not_set_case:
+		}
+	}
+}
+
+type daemonServer struct {
+	bonkv0.UnimplementedBonkDaemonServiceServer
+
+	// Guards the session, so that only one build runs at a time.
+	mu       sync.Mutex
+	sess     *session
+	settings daemonSettings
+	stop     func()
+}
+
+// The settings which are fixed when the daemon starts, since it keeps the caches and plugins
+// they configure open between builds. Paths are absolute, so that they may be compared with
+// those of another process.
+type daemonSettings struct {
+	remoteCache string
+	cacheDir    string
+	pluginDir   string
+}
+
+func currentDaemonSettings() daemonSettings {
+	return daemonSettings{
+		remoteCache: viper.GetString("remote-cache"),
+		cacheDir:    absPath(userCacheDirectory("cache-dir", "cache")),
+		pluginDir:   absPath(pluginDirectory()),
+	}
+}
+
+// Returns an error naming the first setting of the request which differs from the daemon's.
+func (d *daemonServer) checkSettings(req *bonkv0.BuildRequest) error {
+	var flag string
+
+	switch {
+	case req.GetRemoteCache() != d.settings.remoteCache:
+		flag = "--remote-cache"
+	case req.GetCacheDir() != d.settings.cacheDir:
+		flag = "--cache-dir"
+	case req.GetPluginDir() != d.settings.pluginDir:
+		flag = "--plugin-dir"
+	default:
+		return nil
+	}
+
+	return fmt.Errorf(
+		"the daemon was started with a different %s, so restart it or build with --no-daemon",
+		flag,
+	)
+}
+
+// Returns the absolute form of path, or path itself if it is empty or can't be resolved.
+func absPath(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+
+	return abs
+}
+
+func (d *daemonServer) Build(
+	req *bonkv0.BuildRequest,
+	stream grpc.ServerStreamingServer[bonkv0.BuildResponse],
+) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// Tasks log concurrently, but the stream may only be sent to by one goroutine at a time
+	var sendMu sync.Mutex
+
+	ctx := context.WithValue(stream.Context(), logSinkKey{}, logSink(func(record *bonkv0.LogRecord) {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+
+		_ = stream.Send(bonkv0.BuildResponse_builder{Log: record}.Build())
+	}))
+
+	result := bonkv0.BuildResult_builder{}
+
+	err := d.build(ctx, req)
+	if err != nil {
+		message := err.Error()
+		result.Error = &message
+	}
+
+	sendMu.Lock()
+	defer sendMu.Unlock()
+
+	return stream.Send(bonkv0.BuildResponse_builder{Result: result.Build()}.Build())
+}
+
+func (d *daemonServer) build(ctx context.Context, req *bonkv0.BuildRequest) error {
+	slog.InfoContext(ctx, "performing build")
+
+	err := d.checkSettings(req)
+	if err != nil {
+		return err
+	}
+
+	opts := buildOptions{
+		keepGoing:     req.GetKeepGoing(),
+		strictOutputs: req.GetStrictOutputs(),
+		noCache:       req.GetNoCache(),
+		reverseDeps:   req.GetReverseDependencies(),
+		concurrency:   uint(req.GetConcurrency()),
+		profileFile:   req.GetProfile(),
+	}
+
+	// Fall back to the daemon's own concurrency if the client didn't send one
+	if opts.concurrency == 0 {
+		opts.concurrency = concurrency
+	}
+
+	// Plugins started for this build outlive it, so they mustn't be stopped with the request
+	err = d.sess.reload(context.WithoutCancel(ctx), req.GetTargets(), opts.reverseDeps)
+	if err != nil {
+		return err
+	}
+
+	err = configureBackends(d.sess, opts)
+	if err != nil {
+		return err
+	}
+
+	return runBuild(ctx, d.sess, opts)
+}
+
+func (d *daemonServer) Shutdown(
+	ctx context.Context,
+	req *bonkv0.ShutdownRequest,
+) (*bonkv0.ShutdownResponse, error) {
+	slog.InfoContext(ctx, "stopping daemon")
+
+	// Stop once the response has been sent, since stopping waits for open requests
+	go d.stop()
+
+	return &bonkv0.ShutdownResponse{}, nil
+}
+
+type (
+	logSinkKey struct{}
+	logSink    func(record *bonkv0.LogRecord)
+)
+
+// Passes records to the wrapped handler, and to the log sink of the context they are logged
+// with, if it has one.
+type forwardingHandler struct {
+	slog.Handler
+
+	attrs []slog.Attr
+}
+
+func (h *forwardingHandler) Handle(ctx context.Context, record slog.Record) error {
+	sink, ok := ctx.Value(logSinkKey{}).(logSink)
+	if ok {
+		attributes := make(map[string]string, len(h.attrs)+record.NumAttrs())
+
+		addAttr := func(attr slog.Attr) bool {
+			attributes[attr.Key] = attr.Value.Resolve().String()
+
+			return true
+		}
+
+		for _, attr := range h.attrs {
+			addAttr(attr)
+		}
+
+		record.Attrs(addAttr)
+
+		sink(bonkv0.LogRecord_builder{
+			Severity:   bonk.LevelSeverity(record.Level).Enum(),
+			Message:    &record.Message,
+			Attributes: attributes,
+		}.Build())
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *forwardingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &forwardingHandler{
+		Handler: h.Handler.WithAttrs(attrs),
+		attrs:   append(slices.Clip(h.attrs), attrs...),
+	}
+}
+
+func (h *forwardingHandler) WithGroup(name string) slog.Handler {
+	return &forwardingHandler{
+		Handler: h.Handler.WithGroup(name),
+		attrs:   h.attrs,
+	}
+}
+
+func init() {
+	daemonCmd.AddCommand(daemonStopCmd)
+	rootCmd.AddCommand(daemonCmd)
+}
@@ -0,0 +1,54 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// explainCmd represents the explain command.
+var explainCmd = &cobra.Command{
+	Use:   "explain <task>",
+	Short: "Explains why a task would be rebuilt",
+	Long: `Explains why a task would be rebuilt, by comparing the inputs, parameters, and backend
+recorded by its last successful build against their current state.`,
+
+	Args: cobra.ExactArgs(1),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sess, err := openSession(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer sess.Close()
+
+		tsk, err := sess.findTask(args[0])
+		if err != nil {
+			return err
+		}
+
+		reasons := tsk.Explain()
+
+		out := cmd.OutOrStdout()
+		if len(reasons) == 0 {
+			fmt.Fprintf(out, "%s is up to date\n", tsk.ID.String())
+
+			return nil
+		}
+
+		fmt.Fprintf(out, "%s is stale:\n", tsk.ID.String())
+
+		for _, reason := range reasons {
+			fmt.Fprintf(out, "  - %s\n", reason)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+}
@@ -0,0 +1,104 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"go.bonk.build/pkg/scheduler"
+)
+
+var (
+	graphFormat string
+	graphOutput string
+)
+
+// Writes a graph in each of the supported formats.
+var graphWriters = map[string]func(*scheduler.Graph, io.Writer) error{
+	"dot":     (*scheduler.Graph).WriteDOT,
+	"mermaid": (*scheduler.Graph).WriteMermaid,
+	"json":    (*scheduler.Graph).WriteJSON,
+}
+
+// graphCmd represents the graph command.
+var graphCmd = &cobra.Command{
+	Use:   "graph [targets...]",
+	Short: "Prints the graph of tasks a build would schedule",
+	Long: `Prints the graph of tasks a build would schedule, with edges pointing from each task to
+its dependents. Tasks are annotated with their backend, their inputs, and whether they are up to
+date. Targets select tasks the same way as bonk build.
+
+The graph is printed in one of the following formats:
+  dot      a Graphviz digraph, which may be rendered with dot -Tsvg
+  mermaid  a Mermaid flowchart, which may be embedded in Markdown
+  json     the tasks, their dependencies, and why stale tasks would execute`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		writeGraph, ok := graphWriters[graphFormat]
+		if !ok {
+			return fmt.Errorf("unknown graph format %q", graphFormat)
+		}
+
+		sess, err := openSession(cmd.Context())
+		if err != nil {
+			return err
+		}
+		defer sess.Close()
+
+		err = sess.selectTargets(args, reverseDeps)
+		if err != nil {
+			return err
+		}
+
+		sched, predictions, err := runDryRun(cmd.Context(), sess)
+		if err != nil {
+			return err
+		}
+
+		graph := sched.Graph(predictions)
+
+		if graphOutput == "" {
+			err = writeGraph(graph, cmd.OutOrStdout())
+			if err != nil {
+				return fmt.Errorf("failed to write graph: %w", err)
+			}
+
+			return nil
+		}
+
+		file, err := os.Create(graphOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create graph file: %w", err)
+		}
+
+		err = writeGraph(graph, file)
+		if err != nil {
+			_ = file.Close()
+
+			return fmt.Errorf("failed to write graph: %w", err)
+		}
+
+		err = file.Close()
+		if err != nil {
+			return fmt.Errorf("failed to close graph file: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	graphCmd.Flags().
+		StringVarP(&graphFormat, "format", "f", "dot", "the format to print the graph in: dot, mermaid, or json")
+	graphCmd.Flags().
+		StringVarP(&graphOutput, "output", "o", "", "the file to write the graph to (default is stdout)")
+	graphCmd.Flags().
+		BoolVar(&reverseDeps, "rdeps", false, "also include every task which depends on a target")
+
+	rootCmd.AddCommand(graphCmd)
+}
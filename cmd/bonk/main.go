@@ -4,25 +4,21 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
-	"path"
-
-	"cuelang.org/go/cue/ast"
-	"cuelang.org/go/cue/cuecontext"
+	"os/signal"
+	"path/filepath"
+	"syscall"
 
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-
-	"go.bonk.build/pkg/backend"
-	"go.bonk.build/pkg/plugin"
-	"go.bonk.build/pkg/scheduler"
-	"go.bonk.build/pkg/task"
 )
 
 var (
 	cfgFile     string
+	directory   string
 	concurrency uint
 )
 
@@ -31,73 +27,39 @@ var rootCmd = &cobra.Command{
 	Use:   "bonk",
 	Short: "A cue-based configuration build system.",
 
-	Run: func(cmd *cobra.Command, args []string) {
-		cuectx := cuecontext.New()
-
-		bem := backend.NewBackendManager()
-		defer bem.Shutdown()
-
-		pum := plugin.NewPluginManager(bem)
-		defer pum.Shutdown()
-
-		sched := scheduler.NewScheduler(bem, concurrency)
-		defer sched.Run()
-
-		plugins := []string{
-			"go.bonk.build/plugins/test",
-			"go.bonk.build/plugins/k8s/resources",
-			"go.bonk.build/plugins/k8s/kustomize",
-		}
-
-		for _, pluginPath := range plugins {
-			cobra.CheckErr(pum.StartPlugin(cmd.Context(), pluginPath))
-		}
-
-		cobra.CheckErr(
-			sched.AddTask(
-				task.New(
-					"test:Test",
-					"Test.Test",
-					cuectx.CompileString(`value: 3`),
-				),
-			),
-		)
-
-		cobra.CheckErr(
-			sched.AddTask(
-				task.New(
-					"resources:Resources",
-					"Test.Resources",
-					cuectx.CompileString(`
-					resources: [{
-						apiVersion: "v1"
-						kind: "Namespace"
-						metadata: name: "Testing"
-					}]`),
-				),
-			),
-		)
-
-		cwd, _ := os.Getwd()
-		cobra.CheckErr(
-			sched.AddTask(
-				task.New(
-					"kustomize:Kustomize",
-					"Test.Kustomize",
-					cuectx.BuildExpr(ast.NewStruct()),
-					path.Join(cwd, ".bonk/Test.Resources:resources:Resources/resources.yaml"),
-				),
-				"Test.Resources:resources:Resources",
-			),
-		)
-	},
+	// Errors are logged by main
+	SilenceErrors: true,
+	SilenceUsage:  true,
 }
 
 func init() {
+	cobra.OnInitialize(initConfig)
+
 	rootCmd.PersistentFlags().
 		StringVarP(&cfgFile, "config", "c", "", "config file (default is .bonk.yaml)")
+	rootCmd.PersistentFlags().
+		StringVarP(&directory, "directory", "C", "", "the project directory (default is .)")
 	rootCmd.PersistentFlags().
 		UintVarP(&concurrency, "concurrency", "j", 100, "The number of goroutines to run")
+	rootCmd.PersistentFlags().
+		String("plugin-dir", "", "prebuilt plugin directory (default is $XDG_CACHE_HOME/bonk/plugins)")
+	rootCmd.PersistentFlags().
+		String("cache-dir", "", "local build cache directory (default is $XDG_CACHE_HOME/bonk/cache)")
+	rootCmd.PersistentFlags().
+		String("remote-cache", "", "url of a remote build cache, either http[s]://host/path or grpc[s]://host:port/instance")
+
+	cobra.CheckErr(viper.BindPFlag("plugin-dir", rootCmd.PersistentFlags().Lookup("plugin-dir")))
+	cobra.CheckErr(viper.BindPFlag("cache-dir", rootCmd.PersistentFlags().Lookup("cache-dir")))
+	cobra.CheckErr(
+		viper.BindPFlag("remote-cache", rootCmd.PersistentFlags().Lookup("remote-cache")),
+	)
+}
+
+func initConfig() {
+	if directory != "" {
+		slog.Debug("changing directory", "dir", directory)
+		cobra.CheckErr(os.Chdir(directory))
+	}
 
 	if cfgFile != "" {
 		// Use config file from the flag.
@@ -105,7 +67,7 @@ func init() {
 	} else {
 		// Search config in current directory with name ".bonk.yaml".
 		viper.AddConfigPath(".")
-		viper.SetConfigName(".bonk.yaml")
+		viper.SetConfigName(".bonk")
 	}
 
 	viper.AutomaticEnv()
@@ -119,6 +81,24 @@ func init() {
 	}
 }
 
+// Returns the directory configured by key, defaulting to name within the bonk user cache
+// directory. Returns an empty string if neither is available.
+func userCacheDirectory(key, name string) string {
+	dir := viper.GetString(key)
+	if dir != "" {
+		return dir
+	}
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		slog.Debug("no user cache directory", "key", key, "error", err.Error())
+
+		return ""
+	}
+
+	return filepath.Join(cacheDir, "bonk", name)
+}
+
 func main() {
 	slog.SetDefault(
 		slog.New(
@@ -131,7 +111,12 @@ func main() {
 		),
 	)
 
-	err := rootCmd.Execute()
+	// Cancel any in-flight tasks when interrupted
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+
 	if err != nil {
 		slog.Error(err.Error())
 		os.Exit(1)
@@ -0,0 +1,83 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"cuelang.org/go/cue/cuecontext"
+
+	"github.com/spf13/cobra"
+
+	"go.bonk.build/pkg/plugin"
+	"go.bonk.build/pkg/project"
+)
+
+// pluginCmd represents the plugin command.
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Manages bonk plugins",
+}
+
+// pluginInstallCmd represents the plugin install command.
+var pluginInstallCmd = &cobra.Command{
+	Use:   "install [plugins...]",
+	Short: "Compiles plugins into the plugin directory",
+	Long: `Compiles plugins into the plugin directory, keyed by module path and version.
+
+If no plugins are specified, the versioned plugins required by the project are installed.
+Plugins without a version are always run from source, so they are never installed.`,
+
+	Args: cobra.ArbitraryArgs,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plugins := args
+		if len(plugins) == 0 {
+			proj, err := project.Load(cuecontext.New(), ".")
+			if err != nil {
+				return fmt.Errorf("failed to load project: %w", err)
+			}
+
+			required, err := requiredPlugins(proj)
+			if err != nil {
+				return err
+			}
+
+			for _, pluginRef := range required {
+				_, version := plugin.ParseReference(pluginRef)
+				if version == "" {
+					slog.InfoContext(cmd.Context(), "skipping unversioned plugin", "plugin", pluginRef)
+
+					continue
+				}
+
+				plugins = append(plugins, pluginRef)
+			}
+		}
+
+		pluginDir := pluginDirectory()
+
+		for _, pluginRef := range plugins {
+			binPath, err := plugin.Install(cmd.Context(), pluginDir, pluginRef)
+			if err != nil {
+				return err
+			}
+
+			slog.InfoContext(cmd.Context(), "installed plugin", "plugin", pluginRef, "path", binPath)
+		}
+
+		return nil
+	},
+}
+
+// Returns the configured plugin directory, defaulting to the user cache directory.
+func pluginDirectory() string {
+	return userCacheDirectory("plugin-dir", "plugins")
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginInstallCmd)
+	rootCmd.AddCommand(pluginCmd)
+}
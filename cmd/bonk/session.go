@@ -0,0 +1,196 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+
+	"go.bonk.build/pkg/backend"
+	"go.bonk.build/pkg/cache"
+	"go.bonk.build/pkg/plugin"
+	"go.bonk.build/pkg/project"
+	"go.bonk.build/pkg/scheduler"
+	"go.bonk.build/pkg/task"
+)
+
+// The project loaded by a command, along with the plugins and backends its tasks require.
+type session struct {
+	cuectx     *cue.Context
+	project    *project.Project
+	backends   *backend.BackendManager
+	plugins    *plugin.PluginManager
+	fileHashes *task.FileHashes
+	tasks      []task.Task
+
+	// The references of the running plugins keyed by module path, which stay running across
+	// reloads until their version changes or the project no longer requires them.
+	started map[string]string
+
+	// The build cache, opened by the first build so that later builds share its connections.
+	buildCache cache.Cache
+}
+
+// Loads the project in the working directory, starts the plugins it requires, and collects
+// its tasks. The session must be closed to stop the plugins.
+func openSession(ctx context.Context) (*session, error) {
+	sess := &session{
+		cuectx:     cuecontext.New(),
+		backends:   backend.NewBackendManager(),
+		fileHashes: task.LoadFileHashes(task.GetFileHashesFile(), concurrency),
+		started:    make(map[string]string),
+	}
+	sess.backends.SetFileHashes(sess.fileHashes)
+	sess.plugins = plugin.NewPluginManager(sess.backends, pluginDirectory())
+
+	err := sess.reload(ctx, nil, false)
+	if err != nil {
+		sess.Close()
+
+		return nil, err
+	}
+
+	return sess, nil
+}
+
+// Reloads the project and its tasks, starting any plugins it newly requires, restarting those
+// whose version changed, and stopping those it no longer requires. The tasks are narrowed as by
+// selectTargets, and the session's project and tasks are left as they were on failure.
+func (s *session) reload(ctx context.Context, targets []string, reverseDeps bool) error {
+	proj, err := project.Load(s.cuectx, ".")
+	if err != nil {
+		return fmt.Errorf("failed to load project: %w", err)
+	}
+
+	plugins, err := requiredPlugins(proj)
+	if err != nil {
+		return err
+	}
+
+	required := make(map[string]bool, len(plugins))
+	for _, pluginRef := range plugins {
+		pluginPath, _ := plugin.ParseReference(pluginRef)
+		required[pluginPath] = true
+	}
+
+	for pluginPath := range s.started {
+		if !required[pluginPath] {
+			s.plugins.StopPlugin(pluginPath)
+			delete(s.started, pluginPath)
+		}
+	}
+
+	for _, pluginRef := range plugins {
+		pluginPath, _ := plugin.ParseReference(pluginRef)
+
+		startedRef, ok := s.started[pluginPath]
+		if ok && startedRef == pluginRef {
+			continue
+		}
+
+		// Restart plugins whose version changed
+		if ok {
+			s.plugins.StopPlugin(pluginPath)
+			delete(s.started, pluginPath)
+		}
+
+		err = s.plugins.StartPlugin(ctx, pluginRef)
+		if err != nil {
+			// Clean up anything registered before the failure, so the next reload can retry
+			s.plugins.StopPlugin(pluginPath)
+
+			return fmt.Errorf("failed to start plugin %s: %w", pluginRef, err)
+		}
+
+		s.started[pluginPath] = pluginRef
+	}
+
+	tasks, err := proj.Tasks(ctx, s.plugins, s.backends)
+	if err != nil {
+		return fmt.Errorf("failed to load project tasks: %w", err)
+	}
+
+	tasks, err = selectTasks(tasks, targets, reverseDeps)
+	if err != nil {
+		return err
+	}
+
+	s.project = proj
+	s.tasks = tasks
+
+	return nil
+}
+
+// Narrows the session's tasks to the targets and their dependencies, along with their
+// dependents if reverseDeps is set. Does nothing if there are no targets.
+func (s *session) selectTargets(targets []string, reverseDeps bool) error {
+	tasks, err := selectTasks(s.tasks, targets, reverseDeps)
+	if err != nil {
+		return err
+	}
+
+	s.tasks = tasks
+
+	return nil
+}
+
+func selectTasks(tasks []task.Task, targets []string, reverseDeps bool) ([]task.Task, error) {
+	if len(targets) == 0 {
+		if reverseDeps {
+			return nil, errors.New("--rdeps requires at least one target")
+		}
+
+		return tasks, nil
+	}
+
+	return scheduler.SelectTargets(tasks, targets, reverseDeps)
+}
+
+// Returns the task with the given id, either as declared or in the form id:backend.
+func (s *session) findTask(id string) (task.Task, error) {
+	for _, tsk := range s.tasks {
+		if tsk.ID.Name() == id || tsk.ID.String() == id {
+			tsk.Hashes = s.fileHashes
+
+			return tsk, nil
+		}
+	}
+
+	return task.Task{}, fmt.Errorf("no task with id %s", id)
+}
+
+// Returns the session's build cache, opening it on first use.
+func (s *session) cache() (cache.Cache, error) {
+	if s.buildCache != nil {
+		return s.buildCache, nil
+	}
+
+	buildCache, err := openCache()
+	if err != nil {
+		return nil, err
+	}
+
+	s.buildCache = buildCache
+
+	return buildCache, nil
+}
+
+func (s *session) Close() {
+	s.plugins.Shutdown()
+	s.backends.Shutdown()
+
+	closer, ok := s.buildCache.(io.Closer)
+	if ok {
+		err := closer.Close()
+		if err != nil {
+			slog.Warn("failed to close build cache", "error", err.Error())
+		}
+	}
+}
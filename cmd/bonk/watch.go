@@ -0,0 +1,268 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+)
+
+// How long the file system must be quiet before a rebuild starts.
+const watchDebounce = 200 * time.Millisecond
+
+// watchCmd represents the watch command.
+var watchCmd = &cobra.Command{
+	Use:   "watch [targets...]",
+	Short: "Rebuilds tasks whenever their inputs change",
+	Long: `Builds the tasks declared by the project, then watches their inputs and the project's
+cue files, rebuilding the stale tasks and their dependents whenever they change. Plugins stay
+running between builds, and changes to the project's cue files reload its tasks.
+
+Targets select tasks the same way as bonk build.`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		opts := flagBuildOptions()
+
+		sess, err := openSession(ctx)
+		if err != nil {
+			return err
+		}
+		defer sess.Close()
+
+		err = sess.selectTargets(args, opts.reverseDeps)
+		if err != nil {
+			return err
+		}
+
+		err = configureBackends(sess, opts)
+		if err != nil {
+			return err
+		}
+
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return fmt.Errorf("failed to create file watcher: %w", err)
+		}
+		defer watcher.Close()
+
+		for {
+			// Watch before building, so that changes made during the build aren't missed
+			inputs, roots := sess.watchInputs()
+			updateWatches(ctx, watcher, inputs, roots)
+
+			err = runBuild(ctx, sess, opts)
+			if err != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "build failed", "error", err)
+			}
+
+			slog.InfoContext(ctx, "watching for changes", "inputs", len(inputs))
+
+			reload, err := waitForChanges(ctx, watcher, inputs, roots)
+			if err != nil {
+				return err
+			}
+
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			if reload {
+				slog.InfoContext(ctx, "reloading project")
+
+				err = sess.reload(ctx, args, opts.reverseDeps)
+				if err != nil {
+					slog.ErrorContext(ctx, "failed to reload project", "error", err)
+				}
+			}
+		}
+	},
+}
+
+// Returns the absolute paths of the session's task inputs, leaving out the outputs of other
+// tasks, which change as a result of building. Also returns the directories which directory
+// and glob inputs were expanded from, since files may be added anywhere beneath them.
+func (s *session) watchInputs() (map[string]bool, []string) {
+	outputDir, _ := filepath.Abs(".bonk")
+	inputs := make(map[string]bool)
+	roots := []string{}
+
+	for _, tsk := range s.tasks {
+		for _, input := range tsk.Inputs {
+			input, err := filepath.Abs(input)
+			if err != nil || isWithin(outputDir, input) {
+				continue
+			}
+
+			inputs[input] = true
+		}
+
+		for _, root := range s.project.InputDirs[tsk.ID.String()] {
+			if !isWithin(outputDir, root) && !slices.Contains(roots, root) {
+				roots = append(roots, root)
+			}
+		}
+	}
+
+	return inputs, roots
+}
+
+// Watches the project directory, every directory containing an input, and every directory
+// beneath the roots, and stops watching directories which are no longer needed.
+func updateWatches(
+	ctx context.Context,
+	watcher *fsnotify.Watcher,
+	inputs map[string]bool,
+	roots []string,
+) {
+	projectDir, _ := filepath.Abs(".")
+	dirs := map[string]bool{projectDir: true}
+
+	for input := range inputs {
+		dirs[filepath.Dir(input)] = true
+	}
+
+	for _, root := range roots {
+		collectDirs(dirs, root)
+	}
+
+	for _, dir := range watcher.WatchList() {
+		if !dirs[dir] {
+			_ = watcher.Remove(dir)
+		}
+	}
+
+	addWatches(ctx, watcher, dirs)
+}
+
+// Adds dir and every directory beneath it to dirs, leaving out the output directory. Does
+// nothing if dir isn't a directory.
+func collectDirs(dirs map[string]bool, dir string) {
+	outputDir, _ := filepath.Abs(".bonk")
+
+	_ = filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return nil
+		}
+
+		if isWithin(outputDir, file) {
+			return filepath.SkipDir
+		}
+
+		dirs[file] = true
+
+		return nil
+	})
+}
+
+func addWatches(ctx context.Context, watcher *fsnotify.Watcher, dirs map[string]bool) {
+	for dir := range dirs {
+		err := watcher.Add(dir)
+		if err != nil {
+			slog.DebugContext(ctx, "failed to watch directory", "dir", dir, "error", err.Error())
+		}
+	}
+}
+
+// Blocks until a cue file or input changes and the file system has been quiet for the
+// debounce period, or ctx is done. Reports whether the project must be reloaded, which is the
+// case when a cue file changes or files are created or removed, since either may change the
+// tasks or the files their inputs expand to. Directories created beneath the roots are watched
+// as soon as they appear, so that files added to them aren't missed.
+func waitForChanges(
+	ctx context.Context,
+	watcher *fsnotify.Watcher,
+	inputs map[string]bool,
+	roots []string,
+) (bool, error) {
+	outputDir, _ := filepath.Abs(".bonk")
+	changed, reload := false, false
+
+	// The timer only runs once a change has been seen
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false, nil
+
+		case <-timer.C:
+			return reload, nil
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return false, errors.New("file watcher closed")
+			}
+
+			slog.WarnContext(ctx, "file watcher error", "error", err)
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return false, errors.New("file watcher closed")
+			}
+
+			if isWithin(outputDir, event.Name) {
+				continue
+			}
+
+			if event.Has(fsnotify.Create) && slices.ContainsFunc(roots, func(root string) bool {
+				return isWithin(root, event.Name)
+			}) {
+				dirs := make(map[string]bool)
+				collectDirs(dirs, event.Name)
+				addWatches(ctx, watcher, dirs)
+			}
+
+			isCue := filepath.Ext(event.Name) == ".cue"
+			isInput := inputs[event.Name]
+			isStructural := event.Has(fsnotify.Create) ||
+				event.Has(fsnotify.Remove) ||
+				event.Has(fsnotify.Rename)
+
+			if !isCue && !isInput && !isStructural {
+				continue
+			}
+
+			if !changed {
+				slog.DebugContext(ctx, "detected change", "file", event.Name, "op", event.Op.String())
+			}
+
+			changed = true
+			reload = reload || isCue || isStructural
+
+			timer.Reset(watchDebounce)
+		}
+	}
+}
+
+// Reports whether path is dir or lies beneath it.
+func isWithin(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func init() {
+	watchCmd.Flags().
+		BoolVarP(&keepGoing, "keep-going", "k", false, "keep building tasks which don't depend on a failure")
+	watchCmd.Flags().
+		BoolVar(&strictOutputs, "strict-outputs", false, "fail tasks which produce undeclared outputs")
+	watchCmd.Flags().
+		BoolVar(&noCache, "no-cache", false, "don't restore or store task outputs in the build cache")
+	watchCmd.Flags().
+		BoolVar(&reverseDeps, "rdeps", false, "also build every task which depends on a target")
+
+	rootCmd.AddCommand(watchCmd)
+}
@@ -0,0 +1,33 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package example
+
+plugins: [
+	"go.bonk.build/plugins/test",
+	"go.bonk.build/plugins/k8s/resources",
+	"go.bonk.build/plugins/k8s/kustomize",
+]
+
+tasks: {
+	Test: {
+		backend: "test:Test"
+		params: value: 3
+	}
+
+	Resources: {
+		backend: "resources:Resources"
+		params: resources: [{
+			apiVersion: "v1"
+			kind:       "Namespace"
+			metadata: name: "Testing"
+		}]
+	}
+
+	Kustomize: {
+		backend: "kustomize:Kustomize"
+		inputs: [tasks.Resources.outputs["resources.yaml"]]
+	}
+}
+
+frontends: "test:Test": count: 2
@@ -13,5 +13,7 @@ import (
 
 type Backend interface {
 	Outputs() []string
-	Execute(ctx context.Context, cuectx *cue.Context, tsk task.Task) error
+	// Identifies the backend's implementation, such that it changes whenever the backend does.
+	Version() string
+	Execute(ctx context.Context, cuectx *cue.Context, tsk task.Task) (*task.Result, error)
 }
@@ -8,16 +8,24 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
+	"time"
 
 	"cuelang.org/go/cue"
 	"cuelang.org/go/cue/cuecontext"
 
+	"go.bonk.build/pkg/cache"
+	"go.bonk.build/pkg/profile"
 	"go.bonk.build/pkg/task"
 )
 
 type BackendManager struct {
-	cuectx   *cue.Context
-	backends map[string]Backend
+	cuectx        *cue.Context
+	backends      map[string]Backend
+	cache         cache.Cache
+	fileHashes    *task.FileHashes
+	profile       *profile.Profile
+	strictOutputs bool
 }
 
 func NewBackendManager() *BackendManager {
@@ -28,6 +36,28 @@ func NewBackendManager() *BackendManager {
 	return bm
 }
 
+// Restores task outputs from c instead of executing tasks where possible, and stores the
+// outputs of executed tasks in it. A nil cache disables caching.
+func (bm *BackendManager) SetCache(c cache.Cache) {
+	bm.cache = c
+}
+
+// Hashes the inputs of tasks which don't have their own FileHashes with fh.
+func (bm *BackendManager) SetFileHashes(fh *task.FileHashes) {
+	bm.fileHashes = fh
+}
+
+// Records how long each task spends checksumming, fetching from the cache, executing, and
+// storing to the cache in p. A nil profile disables profiling.
+func (bm *BackendManager) SetProfile(p *profile.Profile) {
+	bm.profile = p
+}
+
+// When enabled, tasks fail if their backend produces any files it did not declare as outputs.
+func (bm *BackendManager) SetStrictOutputs(strict bool) {
+	bm.strictOutputs = strict
+}
+
 func (bm *BackendManager) RegisterBackend(name string, impl Backend) error {
 	_, ok := bm.backends[name]
 	if ok {
@@ -43,7 +73,36 @@ func (bm *BackendManager) UnregisterBackend(name string) {
 	delete(bm.backends, name)
 }
 
-func (bm *BackendManager) SendTask(tsk task.Task) error {
+// Returns the outputs declared by the named backend.
+func (bm *BackendManager) Outputs(name string) ([]string, error) {
+	backend, ok := bm.backends[name]
+	if !ok {
+		return nil, fmt.Errorf("Backend %s not found", name)
+	}
+
+	return backend.Outputs(), nil
+}
+
+// Returns the version of the named backend.
+func (bm *BackendManager) Version(name string) (string, error) {
+	backend, ok := bm.backends[name]
+	if !ok {
+		return "", fmt.Errorf("Backend %s not found", name)
+	}
+
+	return backend.Version(), nil
+}
+
+func (bm *BackendManager) SendTask(ctx context.Context, tsk task.Task) error {
+	taskStart := time.Now()
+	outcome := "failed"
+
+	defer func() {
+		bm.profile.Record(tsk.ID.String(), profile.PhaseTask, taskStart, map[string]string{
+			"outcome": outcome,
+		})
+	}()
+
 	backendName := tsk.Backend()
 
 	backend, ok := bm.backends[backendName]
@@ -51,34 +110,143 @@ func (bm *BackendManager) SendTask(tsk task.Task) error {
 		return fmt.Errorf("Backend %s not found", backendName)
 	}
 
+	if tsk.Hashes == nil {
+		tsk.Hashes = bm.fileHashes
+	}
+
+	// Hash the inputs up front so that the time is attributed to checksumming, and so that a
+	// missing input fails the task before its outputs are restored or cleared
+	checksumStart := time.Now()
+
+	_, err := tsk.GenerateChecksum()
+	if err != nil {
+		return fmt.Errorf("failed to checksum task: %w", err)
+	}
+
 	outDir := tsk.GetOutputDirectory()
 	stat, err := os.Stat(outDir)
 	if err != nil || !stat.IsDir() {
+		bm.profile.Record(tsk.ID.String(), profile.PhaseChecksum, checksumStart, nil)
+
 		err := os.MkdirAll(outDir, 0o750)
 		if err != nil {
 			return fmt.Errorf("failed to create temp directory: %w", err)
 		}
-	} else if tsk.CheckChecksum() {
-		slog.Debug("checksums match, skipping task")
+	} else {
+		upToDate := tsk.CheckChecksum()
+
+		bm.profile.Record(tsk.ID.String(), profile.PhaseChecksum, checksumStart, nil)
+
+		if upToDate {
+			slog.DebugContext(ctx, "checksums match, skipping task")
 
-		return nil
+			outcome = "up to date"
+
+			return nil
+		}
 	}
 
-	err = backend.Execute(context.TODO(), bm.cuectx, tsk)
+	if bm.cache != nil {
+		fetchStart := time.Now()
+		restored, err := bm.restoreFromCache(ctx, backend, tsk)
+
+		bm.profile.Record(tsk.ID.String(), profile.PhaseCacheFetch, fetchStart, map[string]string{
+			"hit": strconv.FormatBool(restored),
+		})
+
+		if err != nil {
+			slog.WarnContext(ctx, "failed to restore task from cache",
+				"task", tsk.ID.String(),
+				"error", err,
+			)
+		} else if restored {
+			outcome = "cached"
+
+			return nil
+		}
+	}
+
+	err = clearOutputs(outDir)
+	if err != nil {
+		return err
+	}
+
+	executeStart := time.Now()
+	result, err := backend.Execute(ctx, bm.cuectx, tsk)
+
+	bm.profile.Record(tsk.ID.String(), profile.PhaseExecute, executeStart, nil)
+
 	if err != nil {
 		return fmt.Errorf("failed to execute task: %w", err)
 	}
 
-	slog.Info("task succeeded, saving checksum")
+	if result != nil {
+		for _, diag := range result.Diagnostics {
+			slog.Log(ctx, diag.Severity, diag.Message, "task", tsk.ID.String())
+		}
+	}
+
+	err = verifyOutputs(outDir, backend.Outputs(), bm.strictOutputs)
+	if err != nil {
+		return fmt.Errorf("backend %s produced invalid outputs: %w", backendName, err)
+	}
+
+	slog.InfoContext(ctx, "task succeeded, saving checksum")
+
+	err = tsk.SaveResult(result)
+	if err != nil {
+		return fmt.Errorf("failed to record task result: %w", err)
+	}
 
 	err = tsk.SaveChecksum()
 	if err != nil {
 		return fmt.Errorf("failed to checksum task: %w", err)
 	}
 
+	outcome = "executed"
+
+	if bm.cache != nil {
+		storeStart := time.Now()
+		err = bm.cache.Store(ctx, &tsk)
+
+		bm.profile.Record(tsk.ID.String(), profile.PhaseCacheStore, storeStart, nil)
+
+		if err != nil {
+			slog.WarnContext(ctx, "failed to store task in cache",
+				"task", tsk.ID.String(),
+				"error", err,
+			)
+		}
+	}
+
 	return nil
 }
 
+func (bm *BackendManager) restoreFromCache(
+	ctx context.Context,
+	backend Backend,
+	tsk task.Task,
+) (bool, error) {
+	hit, err := bm.cache.Fetch(ctx, &tsk)
+	if err != nil || !hit {
+		return false, err
+	}
+
+	err = verifyOutputs(tsk.GetOutputDirectory(), backend.Outputs(), bm.strictOutputs)
+	if err != nil {
+		return false, fmt.Errorf("cached outputs are invalid: %w", err)
+	}
+
+	err = tsk.SaveChecksum()
+	if err != nil {
+		return false, fmt.Errorf("failed to checksum task: %w", err)
+	}
+
+	slog.InfoContext(ctx, "restored task outputs from cache", "task", tsk.ID.String())
+
+	return true, nil
+}
+
 func (bm *BackendManager) Shutdown() {
 	bm.backends = make(map[string]Backend)
 }
@@ -0,0 +1,99 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package backend // import "go.bonk.build/pkg/backend"
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.bonk.build/pkg/task"
+)
+
+// Verifies that every declared output exists in outDir. If strict is set, any file which is not
+// covered by a declared output is also an error. Files and directories at the root of outDir
+// starting with a '.' belong to bonk and are ignored.
+func verifyOutputs(outDir string, declared []string, strict bool) error {
+	errs := []error{}
+
+	for _, output := range declared {
+		_, err := os.Stat(filepath.Join(outDir, output))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("declared output %s was not produced: %w", output, err))
+		}
+	}
+
+	if strict {
+		err := filepath.WalkDir(outDir, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			relPath, err := filepath.Rel(outDir, file)
+			if err != nil {
+				return fmt.Errorf("failed to relativize output %s: %w", file, err)
+			}
+
+			if relPath == "." {
+				return nil
+			}
+
+			if task.IsMetadata(relPath) {
+				if entry.IsDir() {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if !entry.IsDir() && !isDeclared(relPath, declared) {
+				errs = append(errs, fmt.Errorf("undeclared output %s was produced", relPath))
+			}
+
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list outputs: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Removes everything in outDir except bonk's metadata, so that files left behind by an earlier
+// execution are never mistaken for outputs of the next one.
+func clearOutputs(outDir string) error {
+	entries, err := os.ReadDir(outDir)
+	if err != nil {
+		return fmt.Errorf("failed to list outputs: %w", err)
+	}
+
+	for _, entry := range entries {
+		if task.IsMetadata(entry.Name()) {
+			continue
+		}
+
+		err = os.RemoveAll(filepath.Join(outDir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to remove stale output %s: %w", entry.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// Returns whether relPath is a declared output, or is within a declared output directory.
+func isDeclared(relPath string, declared []string) bool {
+	for _, output := range declared {
+		output = filepath.Clean(output)
+		if relPath == output || strings.HasPrefix(relPath, output+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
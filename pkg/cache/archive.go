@@ -0,0 +1,132 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package cache // import "go.bonk.build/pkg/cache"
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Writes the files within dir to w as a gzipped tarball.
+func writeArchive(w io.Writer, dir string) error {
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	err := filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(dir, file)
+		if err != nil {
+			return fmt.Errorf("failed to relativize %s: %w", file, err)
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", relPath, err)
+		}
+
+		err = tarWriter.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     filepath.ToSlash(relPath),
+			Size:     info.Size(),
+			Mode:     0o600,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write header for %s: %w", relPath, err)
+		}
+
+		reader, err := os.Open(file)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", relPath, err)
+		}
+		defer reader.Close()
+
+		_, err = io.Copy(tarWriter, reader)
+		if err != nil {
+			return fmt.Errorf("failed to archive %s: %w", relPath, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+
+	return nil
+}
+
+// Extracts a gzipped tarball written by writeArchive from r into dir.
+func extractArchive(r io.Reader, dir string) error {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			return fmt.Errorf("unsupported archive entry %s", header.Name)
+		}
+
+		// Refuse entries which would escape dir
+		relPath := filepath.FromSlash(header.Name)
+		if !filepath.IsLocal(relPath) {
+			return fmt.Errorf("invalid archive entry %s", header.Name)
+		}
+
+		target := filepath.Join(dir, relPath)
+
+		err = os.MkdirAll(filepath.Dir(target), 0o750)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+		}
+
+		writer, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", target, err)
+		}
+
+		_, err = io.Copy(writer, tarReader)
+		if err == nil {
+			err = writer.Close()
+		} else {
+			_ = writer.Close()
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to extract %s: %w", header.Name, err)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package cache // import "go.bonk.build/pkg/cache"
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.bonk.build/pkg/task"
+)
+
+// A store of task output directories, keyed by the inputs of the task which produced them.
+type Cache interface {
+	// Replaces the contents of the task's output directory with the outputs stored for it.
+	// Returns false if nothing is stored for the task.
+	Fetch(ctx context.Context, tsk *task.Task) (bool, error)
+
+	// Stores the contents of the task's output directory.
+	Store(ctx context.Context, tsk *task.Task) error
+}
+
+// The longest a remote cache may take to fetch or store a task's outputs, so that an
+// unresponsive server can't stall the build.
+const remoteTimeout = 5 * time.Minute
+
+// Returns the string form of a digest used to name cache entries.
+func Key(digest []byte) string {
+	return hex.EncodeToString(digest)
+}
+
+// Returns the checksum identifying the task's cache entry, and its output directory.
+func taskEntry(tsk *task.Task) ([]byte, string, error) {
+	digest, err := tsk.GenerateChecksum()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to checksum task: %w", err)
+	}
+
+	return digest, tsk.GetOutputDirectory(), nil
+}
+
+// Replaces the contents of outDir with the outputs written by fill into a temporary directory,
+// so that a broken transfer leaves outDir intact.
+func restoreOutputs(outDir string, fill func(tmpDir string) error) error {
+	tmpDir, err := os.MkdirTemp(filepath.Dir(outDir), ".cache-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	err = fill(tmpDir)
+	if err != nil {
+		return err
+	}
+
+	err = clearDir(outDir)
+	if err != nil {
+		return err
+	}
+
+	err = copyTree(tmpDir, outDir)
+	if err != nil {
+		return fmt.Errorf("failed to restore cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// Removes everything within dir, leaving dir itself in place.
+func clearDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		err = os.RemoveAll(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to remove %s: %w", entry.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// Recursively copies the files within src into dst.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(src, file)
+		if err != nil {
+			return fmt.Errorf("failed to relativize %s: %w", file, err)
+		}
+
+		target := filepath.Join(dst, relPath)
+
+		if entry.IsDir() {
+			err = os.MkdirAll(target, 0o750)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", target, err)
+			}
+
+			return nil
+		}
+
+		return copyFile(file, target)
+	})
+}
+
+func copyFile(src, dst string) error {
+	reader, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", src, err)
+	}
+	defer reader.Close()
+
+	writer, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", dst, err)
+	}
+
+	_, err = io.Copy(writer, reader)
+	if err != nil {
+		_ = writer.Close()
+
+		return fmt.Errorf("failed to copy %s: %w", src, err)
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close %s: %w", dst, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package cache // import "go.bonk.build/pkg/cache"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"go.bonk.build/pkg/task"
+)
+
+// A cache which stores outputs on an HTTP server, which must respond to GET and PUT requests
+// on <base>/<digest> with gzipped tarballs of the output directory, such as `bonk cache serve`.
+type HTTPCache struct {
+	baseURL *url.URL
+	client  *http.Client
+}
+
+func NewHTTPCache(baseURL string) (*HTTPCache, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cache url: %w", err)
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported cache url scheme %q", parsed.Scheme)
+	}
+
+	return &HTTPCache{
+		baseURL: parsed,
+		client:  &http.Client{Timeout: remoteTimeout},
+	}, nil
+}
+
+func (hc *HTTPCache) entryURL(digest []byte) string {
+	return hc.baseURL.JoinPath(Key(digest)).String()
+}
+
+func (hc *HTTPCache) Fetch(ctx context.Context, tsk *task.Task) (bool, error) {
+	digest, outDir, err := taskEntry(tsk)
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, hc.entryURL(digest), nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := hc.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch cache entry: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to fetch cache entry: %s", resp.Status)
+	}
+
+	err = restoreOutputs(outDir, func(tmpDir string) error {
+		err := extractArchive(resp.Body, tmpDir)
+		if err != nil {
+			return fmt.Errorf("failed to restore cache entry: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (hc *HTTPCache) Store(ctx context.Context, tsk *task.Task) error {
+	digest, outDir, err := taskEntry(tsk)
+	if err != nil {
+		return err
+	}
+
+	reader, writer := io.Pipe()
+
+	go func() {
+		writer.CloseWithError(writeArchive(writer, outDir))
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, hc.entryURL(digest), reader)
+	if err != nil {
+		_ = reader.Close()
+
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", archiveContentType)
+
+	resp, err := hc.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to store cache entry: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to store cache entry: %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,292 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package reapitest // import "go.bonk.build/pkg/cache/internal/reapitest"
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/genproto/googleapis/bytestream"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bazelbuild/remote-apis/build/bazel/semver"
+
+	repb "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// The largest batch request the server accepts, matching the gRPC default message size limit.
+const MaxBatchSize = 4 << 20
+
+// The size of the chunks blobs are streamed in.
+const chunkSize = 1 << 20
+
+// An in-memory implementation of the REAPI ActionCache, ContentAddressableStorage,
+// Capabilities, and ByteStream services, for exercising REAPICache without an external cache.
+// Instance names are ignored.
+type Server struct {
+	repb.UnimplementedActionCacheServer
+	repb.UnimplementedContentAddressableStorageServer
+	repb.UnimplementedCapabilitiesServer
+	bytestream.UnimplementedByteStreamServer
+
+	mutex   sync.RWMutex
+	results map[string]*repb.ActionResult
+	blobs   map[string][]byte
+}
+
+func NewServer() *Server {
+	return &Server{
+		results: make(map[string]*repb.ActionResult),
+		blobs:   make(map[string][]byte),
+	}
+}
+
+// Registers the server's services with server.
+func (ms *Server) Register(server *grpc.Server) {
+	repb.RegisterActionCacheServer(server, ms)
+	repb.RegisterContentAddressableStorageServer(server, ms)
+	repb.RegisterCapabilitiesServer(server, ms)
+	bytestream.RegisterByteStreamServer(server, ms)
+}
+
+func (ms *Server) GetActionResult(
+	_ context.Context,
+	req *repb.GetActionResultRequest,
+) (*repb.ActionResult, error) {
+	ms.mutex.RLock()
+	defer ms.mutex.RUnlock()
+
+	result, ok := ms.results[req.GetActionDigest().GetHash()]
+	if !ok {
+		return nil, grpcstatus.Error(codes.NotFound, "action result not found")
+	}
+
+	// Results may only be served while all of their outputs are available
+	for _, file := range result.GetOutputFiles() {
+		_, ok = ms.blobs[file.GetDigest().GetHash()]
+		if !ok {
+			return nil, grpcstatus.Error(codes.NotFound, "action result outputs are missing")
+		}
+	}
+
+	return proto.CloneOf(result), nil
+}
+
+func (ms *Server) UpdateActionResult(
+	_ context.Context,
+	req *repb.UpdateActionResultRequest,
+) (*repb.ActionResult, error) {
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	ms.results[req.GetActionDigest().GetHash()] = proto.CloneOf(req.GetActionResult())
+
+	return req.GetActionResult(), nil
+}
+
+func (ms *Server) FindMissingBlobs(
+	_ context.Context,
+	req *repb.FindMissingBlobsRequest,
+) (*repb.FindMissingBlobsResponse, error) {
+	ms.mutex.RLock()
+	defer ms.mutex.RUnlock()
+
+	missing := []*repb.Digest{}
+
+	for _, digest := range req.GetBlobDigests() {
+		_, ok := ms.blobs[digest.GetHash()]
+		if !ok {
+			missing = append(missing, digest)
+		}
+	}
+
+	return &repb.FindMissingBlobsResponse{
+		MissingBlobDigests: missing,
+	}, nil
+}
+
+func (ms *Server) BatchUpdateBlobs(
+	_ context.Context,
+	req *repb.BatchUpdateBlobsRequest,
+) (*repb.BatchUpdateBlobsResponse, error) {
+	// Real servers reject messages over the gRPC limit, so larger blobs must be streamed
+	if proto.Size(req) > MaxBatchSize {
+		return nil, grpcstatus.Error(codes.ResourceExhausted, "batch is too large")
+	}
+
+	ms.mutex.Lock()
+	defer ms.mutex.Unlock()
+
+	responses := make([]*repb.BatchUpdateBlobsResponse_Response, 0, len(req.GetRequests()))
+
+	for _, blob := range req.GetRequests() {
+		hash := sha256.Sum256(blob.GetData())
+
+		code := codes.OK
+		if hex.EncodeToString(hash[:]) != blob.GetDigest().GetHash() {
+			code = codes.InvalidArgument
+		} else {
+			ms.blobs[blob.GetDigest().GetHash()] = blob.GetData()
+		}
+
+		responses = append(responses, &repb.BatchUpdateBlobsResponse_Response{
+			Digest: blob.GetDigest(),
+			Status: &status.Status{Code: int32(code)},
+		})
+	}
+
+	return &repb.BatchUpdateBlobsResponse{
+		Responses: responses,
+	}, nil
+}
+
+func (ms *Server) BatchReadBlobs(
+	_ context.Context,
+	req *repb.BatchReadBlobsRequest,
+) (*repb.BatchReadBlobsResponse, error) {
+	ms.mutex.RLock()
+	defer ms.mutex.RUnlock()
+
+	responses := make([]*repb.BatchReadBlobsResponse_Response, 0, len(req.GetDigests()))
+
+	for _, digest := range req.GetDigests() {
+		data, ok := ms.blobs[digest.GetHash()]
+
+		code := codes.OK
+		if !ok {
+			code = codes.NotFound
+		}
+
+		responses = append(responses, &repb.BatchReadBlobsResponse_Response{
+			Digest: digest,
+			Data:   data,
+			Status: &status.Status{Code: int32(code)},
+		})
+	}
+
+	return &repb.BatchReadBlobsResponse{
+		Responses: responses,
+	}, nil
+}
+
+func (ms *Server) GetCapabilities(
+	context.Context,
+	*repb.GetCapabilitiesRequest,
+) (*repb.ServerCapabilities, error) {
+	return &repb.ServerCapabilities{
+		CacheCapabilities: &repb.CacheCapabilities{
+			DigestFunctions: []repb.DigestFunction_Value{repb.DigestFunction_SHA256},
+			ActionCacheUpdateCapabilities: &repb.ActionCacheUpdateCapabilities{
+				UpdateEnabled: true,
+			},
+			MaxBatchTotalSizeBytes: MaxBatchSize,
+		},
+		LowApiVersion:  &semver.SemVer{Major: 2},
+		HighApiVersion: &semver.SemVer{Major: 2, Minor: 3},
+	}, nil
+}
+
+func (ms *Server) Read(req *bytestream.ReadRequest, stream bytestream.ByteStream_ReadServer) error {
+	hash, _, err := parseResourceName(req.GetResourceName())
+	if err != nil {
+		return err
+	}
+
+	ms.mutex.RLock()
+	data, ok := ms.blobs[hash]
+	ms.mutex.RUnlock()
+
+	if !ok {
+		return grpcstatus.Error(codes.NotFound, "blob not found")
+	}
+
+	if req.GetReadOffset() < 0 || req.GetReadOffset() > int64(len(data)) {
+		return grpcstatus.Error(codes.OutOfRange, "read offset is out of range")
+	}
+
+	data = data[req.GetReadOffset():]
+
+	for len(data) > 0 {
+		chunk := data[:min(len(data), chunkSize)]
+		data = data[len(chunk):]
+
+		err = stream.Send(&bytestream.ReadResponse{Data: chunk})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ms *Server) Write(stream bytestream.ByteStream_WriteServer) error {
+	var (
+		hash string
+		size int64
+		data []byte
+	)
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return grpcstatus.Error(codes.InvalidArgument, "write was not finished")
+		} else if err != nil {
+			return err
+		}
+
+		if hash == "" {
+			hash, size, err = parseResourceName(req.GetResourceName())
+			if err != nil {
+				return err
+			}
+		}
+
+		if req.GetWriteOffset() != int64(len(data)) {
+			return grpcstatus.Error(codes.InvalidArgument, "write offset is out of order")
+		}
+
+		data = append(data, req.GetData()...)
+
+		if req.GetFinishWrite() {
+			break
+		}
+	}
+
+	sum := sha256.Sum256(data)
+	if int64(len(data)) != size || hex.EncodeToString(sum[:]) != hash {
+		return grpcstatus.Error(codes.InvalidArgument, "blob does not match its digest")
+	}
+
+	ms.mutex.Lock()
+	ms.blobs[hash] = data
+	ms.mutex.Unlock()
+
+	return stream.SendAndClose(&bytestream.WriteResponse{CommittedSize: size})
+}
+
+// Returns the hash and size of the blob named by a ByteStream resource name, which ends in
+// blobs/<hash>/<size>.
+func parseResourceName(name string) (string, int64, error) {
+	parts := strings.Split(name, "/")
+	if len(parts) < 3 || parts[len(parts)-3] != "blobs" {
+		return "", 0, grpcstatus.Errorf(codes.InvalidArgument, "invalid resource name %q", name)
+	}
+
+	size, err := strconv.ParseInt(parts[len(parts)-1], 10, 64)
+	if err != nil {
+		return "", 0, grpcstatus.Errorf(codes.InvalidArgument, "invalid resource name %q", name)
+	}
+
+	return parts[len(parts)-2], size, nil
+}
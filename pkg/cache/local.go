@@ -0,0 +1,229 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package cache // import "go.bonk.build/pkg/cache"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"go.bonk.build/pkg/task"
+)
+
+// A cache which stores outputs in a local directory, one subdirectory per digest.
+type LocalCache struct {
+	dir string
+}
+
+func NewLocalCache(dir string) (*LocalCache, error) {
+	err := os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return &LocalCache{
+		dir: dir,
+	}, nil
+}
+
+func (lc *LocalCache) entryDir(digest []byte) string {
+	key := Key(digest)
+
+	return filepath.Join(lc.dir, key[:2], key)
+}
+
+func (lc *LocalCache) Fetch(ctx context.Context, tsk *task.Task) (bool, error) {
+	digest, outDir, err := taskEntry(tsk)
+	if err != nil {
+		return false, err
+	}
+
+	entryDir := lc.entryDir(digest)
+
+	_, err = os.Stat(entryDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to stat cache entry: %w", err)
+	}
+
+	err = clearDir(outDir)
+	if err != nil {
+		return false, err
+	}
+
+	err = copyTree(entryDir, outDir)
+	if err != nil {
+		return false, fmt.Errorf("failed to restore cache entry: %w", err)
+	}
+
+	// Mark the entry as recently used so it survives garbage collection
+	now := time.Now()
+
+	err = os.Chtimes(entryDir, now, now)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to touch cache entry", "entry", entryDir, "error", err)
+	}
+
+	return true, nil
+}
+
+func (lc *LocalCache) Store(_ context.Context, tsk *task.Task) error {
+	digest, outDir, err := taskEntry(tsk)
+	if err != nil {
+		return err
+	}
+
+	entryDir := lc.entryDir(digest)
+
+	_, err = os.Stat(entryDir)
+	if err == nil {
+		// Entries are content addressed, so an existing entry is already correct
+		return nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(entryDir), 0o750)
+	if err != nil {
+		return fmt.Errorf("failed to create cache shard: %w", err)
+	}
+
+	// Copy into a temporary directory and rename it into place, so that fetches made meanwhile
+	// find either no entry or a complete one
+	tmpDir, err := os.MkdirTemp(filepath.Dir(entryDir), ".tmp-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary cache entry: %w", err)
+	}
+
+	err = copyTree(outDir, tmpDir)
+	if err == nil {
+		err = os.Rename(tmpDir, entryDir)
+	}
+
+	if err != nil {
+		_ = os.RemoveAll(tmpDir)
+
+		return fmt.Errorf("failed to store cache entry: %w", err)
+	}
+
+	return nil
+}
+
+type localEntry struct {
+	dir     string
+	size    int64
+	lastUse time.Time
+}
+
+// Removes entries which haven't been used within maxAge, then the least recently used entries
+// until the cache is no larger than maxSize bytes. Zero disables either limit.
+// Returns the number of entries and bytes removed.
+func (lc *LocalCache) GC(
+	ctx context.Context,
+	maxSize int64,
+	maxAge time.Duration,
+) (int, int64, error) {
+	entries, err := lc.listEntries()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Oldest first
+	slices.SortFunc(entries, func(a, b localEntry) int {
+		return a.lastUse.Compare(b.lastUse)
+	})
+
+	totalSize := int64(0)
+	for _, entry := range entries {
+		totalSize += entry.size
+	}
+
+	removed := 0
+	removedSize := int64(0)
+
+	for _, entry := range entries {
+		expired := maxAge > 0 && time.Since(entry.lastUse) > maxAge
+		oversized := maxSize > 0 && totalSize > maxSize
+
+		if !expired && !oversized {
+			continue
+		}
+
+		slog.DebugContext(ctx, "removing cache entry", "entry", entry.dir, "size", entry.size)
+
+		err = os.RemoveAll(entry.dir)
+		if err != nil {
+			return removed, removedSize, fmt.Errorf("failed to remove cache entry: %w", err)
+		}
+
+		removed++
+		removedSize += entry.size
+		totalSize -= entry.size
+	}
+
+	return removed, removedSize, nil
+}
+
+func (lc *LocalCache) listEntries() ([]localEntry, error) {
+	shards, err := os.ReadDir(lc.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cache directory: %w", err)
+	}
+
+	entries := []localEntry{}
+
+	for _, shard := range shards {
+		if !shard.IsDir() {
+			continue
+		}
+
+		shardDir := filepath.Join(lc.dir, shard.Name())
+
+		shardEntries, err := os.ReadDir(shardDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list cache shard: %w", err)
+		}
+
+		for _, shardEntry := range shardEntries {
+			info, err := shardEntry.Info()
+			if err != nil {
+				return nil, fmt.Errorf("failed to stat cache entry: %w", err)
+			}
+
+			entry := localEntry{
+				dir:     filepath.Join(shardDir, shardEntry.Name()),
+				lastUse: info.ModTime(),
+			}
+
+			err = filepath.WalkDir(entry.dir, func(_ string, file fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+
+				if !file.IsDir() {
+					fileInfo, err := file.Info()
+					if err != nil {
+						return err
+					}
+
+					entry.size += fileInfo.Size()
+				}
+
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to size cache entry: %w", err)
+			}
+
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,694 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package cache // import "go.bonk.build/pkg/cache"
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/bytestream"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/google/uuid"
+
+	repb "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+
+	"go.bonk.build/pkg/task"
+)
+
+// The largest request sent to the content addressable storage in one batch. Servers commonly
+// reject gRPC messages above 4MiB, so leave room for the rest of the request. Larger blobs are
+// streamed with the ByteStream API instead.
+const maxBatchSize = 4<<20 - 64<<10
+
+// The size of the chunks blobs are streamed in.
+const streamChunkSize = 1 << 20
+
+// A cache which speaks the Remote Execution API (REAPI) ActionCache and ContentAddressableStorage
+// protocols, such as bazel-remote or buildbarn.
+//
+// Each task is described as an Action whose input root holds the task's input files and paths,
+// parameters, and dependency results and whose command names its backend, so the action digest
+// changes exactly when the task's checksum does. The task's output directory is stored as the ActionResult.
+type REAPICache struct {
+	conn         *grpc.ClientConn
+	instanceName string
+	actionCache  repb.ActionCacheClient
+	cas          repb.ContentAddressableStorageClient
+	byteStream   bytestream.ByteStreamClient
+}
+
+// Creates a cache using an existing connection, such as one to an in-process server.
+func NewREAPICache(conn grpc.ClientConnInterface, instanceName string) *REAPICache {
+	return &REAPICache{
+		instanceName: instanceName,
+		actionCache:  repb.NewActionCacheClient(conn),
+		cas:          repb.NewContentAddressableStorageClient(conn),
+		byteStream:   bytestream.NewByteStreamClient(conn),
+	}
+}
+
+// Connects to the cache at target, in the form grpc[s]://host:port[/instance-name].
+func DialREAPICache(target string) (*REAPICache, error) {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cache url: %w", err)
+	}
+
+	var creds credentials.TransportCredentials
+
+	switch parsed.Scheme {
+	case "grpc":
+		creds = insecure.NewCredentials()
+	case "grpcs":
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	default:
+		return nil, fmt.Errorf("unsupported cache url scheme %q", parsed.Scheme)
+	}
+
+	conn, err := grpc.NewClient(parsed.Host, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to cache: %w", err)
+	}
+
+	rc := NewREAPICache(conn, strings.TrimPrefix(parsed.Path, "/"))
+	rc.conn = conn
+
+	return rc, nil
+}
+
+// Closes the connection created by DialREAPICache.
+func (rc *REAPICache) Close() error {
+	if rc.conn == nil {
+		return nil
+	}
+
+	err := rc.conn.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close cache connection: %w", err)
+	}
+
+	return nil
+}
+
+func (rc *REAPICache) Fetch(ctx context.Context, tsk *task.Task) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteTimeout)
+	defer cancel()
+
+	actionDigest, _, err := buildAction(tsk)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := rc.actionCache.GetActionResult(ctx, &repb.GetActionResultRequest{
+		InstanceName:   rc.instanceName,
+		ActionDigest:   actionDigest,
+		DigestFunction: repb.DigestFunction_SHA256,
+	})
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get action result: %w", err)
+	}
+
+	if result.GetExitCode() != 0 {
+		return false, nil
+	}
+
+	err = restoreOutputs(tsk.GetOutputDirectory(), func(tmpDir string) error {
+		// Outputs which weren't inlined into the result are downloaded afterwards, keyed by hash
+		missing := []*repb.Digest{}
+		targets := make(map[string][]string)
+
+		for _, file := range result.GetOutputFiles() {
+			relPath := filepath.FromSlash(file.GetPath())
+			if !filepath.IsLocal(relPath) {
+				return fmt.Errorf("invalid output path %s", file.GetPath())
+			}
+
+			target := filepath.Join(tmpDir, relPath)
+
+			err := os.MkdirAll(filepath.Dir(target), 0o750)
+			if err != nil {
+				return fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+			}
+
+			if file.GetContents() != nil || file.GetDigest().GetSizeBytes() == 0 {
+				err = os.WriteFile(target, file.GetContents(), 0o600)
+				if err != nil {
+					return fmt.Errorf("failed to write %s: %w", file.GetPath(), err)
+				}
+
+				continue
+			}
+
+			hash := file.GetDigest().GetHash()
+			if targets[hash] == nil {
+				missing = append(missing, file.GetDigest())
+			}
+
+			targets[hash] = append(targets[hash], target)
+		}
+
+		return rc.readBlobs(ctx, missing, targets)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (rc *REAPICache) Store(ctx context.Context, tsk *task.Task) error {
+	ctx, cancel := context.WithTimeout(ctx, remoteTimeout)
+	defer cancel()
+
+	actionDigest, blobs, err := buildAction(tsk)
+	if err != nil {
+		return err
+	}
+
+	outDir := tsk.GetOutputDirectory()
+	outputs := []*repb.OutputFile{}
+
+	err = filepath.WalkDir(outDir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(outDir, file)
+		if err != nil {
+			return fmt.Errorf("failed to relativize %s: %w", file, err)
+		}
+
+		digest, err := fileDigest(file)
+		if err != nil {
+			return fmt.Errorf("failed to hash output %s: %w", relPath, err)
+		}
+
+		blobs[digest.GetHash()] = &casBlob{digest: digest, file: file}
+
+		outputs = append(outputs, &repb.OutputFile{
+			Path:   filepath.ToSlash(relPath),
+			Digest: digest,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to collect task outputs: %w", err)
+	}
+
+	err = rc.uploadBlobs(ctx, blobs)
+	if err != nil {
+		return err
+	}
+
+	_, err = rc.actionCache.UpdateActionResult(ctx, &repb.UpdateActionResultRequest{
+		InstanceName: rc.instanceName,
+		ActionDigest: actionDigest,
+		ActionResult: &repb.ActionResult{
+			OutputFiles: outputs,
+		},
+		DigestFunction: repb.DigestFunction_SHA256,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update action result: %w", err)
+	}
+
+	return nil
+}
+
+// Downloads the given blobs from the content addressable storage, writing each to the files
+// targets lists for its hash. Small blobs are read in batches and large ones are streamed, so
+// that only one batch is held in memory at a time.
+func (rc *REAPICache) readBlobs(
+	ctx context.Context,
+	digests []*repb.Digest,
+	targets map[string][]string,
+) error {
+	batches, streamed := batchDigests(digests)
+
+	for _, digest := range streamed {
+		files := targets[digest.GetHash()]
+
+		err := rc.readBlob(ctx, digest, files[0])
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files[1:] {
+			err = copyFile(files[0], file)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, batch := range batches {
+		resp, err := rc.cas.BatchReadBlobs(ctx, &repb.BatchReadBlobsRequest{
+			InstanceName:   rc.instanceName,
+			Digests:        batch,
+			DigestFunction: repb.DigestFunction_SHA256,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read blobs: %w", err)
+		}
+
+		for _, blob := range resp.GetResponses() {
+			if codes.Code(blob.GetStatus().GetCode()) != codes.OK {
+				return fmt.Errorf(
+					"failed to read blob %s: %s",
+					blob.GetDigest().GetHash(),
+					blob.GetStatus().GetMessage(),
+				)
+			}
+
+			if blobDigest(blob.GetData()).GetHash() != blob.GetDigest().GetHash() {
+				return fmt.Errorf("blob %s was corrupted in transfer", blob.GetDigest().GetHash())
+			}
+
+			for _, file := range targets[blob.GetDigest().GetHash()] {
+				err = os.WriteFile(file, blob.GetData(), 0o600)
+				if err != nil {
+					return fmt.Errorf("failed to write %s: %w", file, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Streams a blob which is too large to batch from the content addressable storage into file,
+// verifying that its contents match its digest.
+func (rc *REAPICache) readBlob(ctx context.Context, digest *repb.Digest, file string) error {
+	stream, err := rc.byteStream.Read(ctx, &bytestream.ReadRequest{
+		ResourceName: rc.resourceName("blobs", digest),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read blob %s: %w", digest.GetHash(), err)
+	}
+
+	writer, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", file, err)
+	}
+	defer writer.Close()
+
+	hasher := sha256.New()
+	out := io.MultiWriter(writer, hasher)
+
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to read blob %s: %w", digest.GetHash(), err)
+		}
+
+		_, err = out.Write(resp.GetData())
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+
+	if hex.EncodeToString(hasher.Sum(nil)) != digest.GetHash() {
+		return fmt.Errorf("blob %s was corrupted in transfer", digest.GetHash())
+	}
+
+	return nil
+}
+
+// Uploads any of the given blobs, keyed by hash, which the content addressable storage is
+// missing.
+func (rc *REAPICache) uploadBlobs(ctx context.Context, blobs map[string]*casBlob) error {
+	digests := make([]*repb.Digest, 0, len(blobs))
+	for _, blob := range blobs {
+		digests = append(digests, blob.digest)
+	}
+
+	resp, err := rc.cas.FindMissingBlobs(ctx, &repb.FindMissingBlobsRequest{
+		InstanceName:   rc.instanceName,
+		BlobDigests:    digests,
+		DigestFunction: repb.DigestFunction_SHA256,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find missing blobs: %w", err)
+	}
+
+	batches, streamed := batchDigests(resp.GetMissingBlobDigests())
+
+	for _, digest := range streamed {
+		err = rc.writeBlob(ctx, blobs[digest.GetHash()])
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, batch := range batches {
+		requests := make([]*repb.BatchUpdateBlobsRequest_Request, 0, len(batch))
+		for _, digest := range batch {
+			data, err := blobs[digest.GetHash()].read()
+			if err != nil {
+				return err
+			}
+
+			requests = append(requests, &repb.BatchUpdateBlobsRequest_Request{
+				Digest: digest,
+				Data:   data,
+			})
+		}
+
+		resp, err := rc.cas.BatchUpdateBlobs(ctx, &repb.BatchUpdateBlobsRequest{
+			InstanceName:   rc.instanceName,
+			Requests:       requests,
+			DigestFunction: repb.DigestFunction_SHA256,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to upload blobs: %w", err)
+		}
+
+		for _, blob := range resp.GetResponses() {
+			if codes.Code(blob.GetStatus().GetCode()) != codes.OK {
+				return fmt.Errorf(
+					"failed to upload blob %s: %s",
+					blob.GetDigest().GetHash(),
+					blob.GetStatus().GetMessage(),
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Streams a blob which is too large to batch to the content addressable storage, one chunk at
+// a time.
+func (rc *REAPICache) writeBlob(ctx context.Context, blob *casBlob) error {
+	reader, err := blob.open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	stream, err := rc.byteStream.Write(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to upload blob %s: %w", blob.digest.GetHash(), err)
+	}
+
+	resourceName := rc.resourceName("uploads/"+uuid.NewString()+"/blobs", blob.digest)
+	chunk := make([]byte, streamChunkSize)
+	offset := int64(0)
+
+	for {
+		n, err := io.ReadFull(reader, chunk)
+		finished := offset+int64(n) >= blob.digest.GetSizeBytes()
+
+		if !finished && err != nil {
+			return fmt.Errorf("failed to read blob %s: %w", blob.digest.GetHash(), err)
+		}
+
+		req := &bytestream.WriteRequest{
+			WriteOffset: offset,
+			Data:        chunk[:n],
+			FinishWrite: finished,
+		}
+
+		// Only the first request of the stream names the resource
+		if offset == 0 {
+			req.ResourceName = resourceName
+		}
+
+		// The server ends the stream early if it already has the blob
+		err = stream.Send(req)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to upload blob %s: %w", blob.digest.GetHash(), err)
+		}
+
+		offset += int64(n)
+
+		if finished {
+			break
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("failed to upload blob %s: %w", blob.digest.GetHash(), err)
+	}
+
+	if resp.GetCommittedSize() != blob.digest.GetSizeBytes() {
+		return fmt.Errorf(
+			"failed to upload blob %s: committed %d of %d bytes",
+			blob.digest.GetHash(),
+			resp.GetCommittedSize(),
+			blob.digest.GetSizeBytes(),
+		)
+	}
+
+	return nil
+}
+
+// Returns the ByteStream resource name of the blob, beneath prefix.
+func (rc *REAPICache) resourceName(prefix string, digest *repb.Digest) string {
+	name := fmt.Sprintf("%s/%s/%d", prefix, digest.GetHash(), digest.GetSizeBytes())
+	if rc.instanceName != "" {
+		name = rc.instanceName + "/" + name
+	}
+
+	return name
+}
+
+// A blob referenced by an action or its result. Small generated blobs are held in memory, while
+// files are only read as they are uploaded.
+type casBlob struct {
+	digest *repb.Digest
+	data   []byte
+	file   string
+}
+
+// Opens the blob's contents for reading.
+func (b *casBlob) open() (io.ReadCloser, error) {
+	if b.file == "" {
+		return io.NopCloser(bytes.NewReader(b.data)), nil
+	}
+
+	reader, err := os.Open(b.file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", b.file, err)
+	}
+
+	return reader, nil
+}
+
+// Returns the blob's contents. Only used for blobs small enough to batch.
+func (b *casBlob) read() ([]byte, error) {
+	reader, err := b.open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", b.file, err)
+	}
+
+	return data, nil
+}
+
+// Describes the task as an Action, returning its digest along with the blobs it references,
+// keyed by hash. The action covers the same inputs as the task checksum, taken from its
+// manifest: the backend and its version, the contents of each input file, the parameters, and
+// the result of each dependency.
+func buildAction(tsk *task.Task) (*repb.Digest, map[string]*casBlob, error) {
+	blobs := make(map[string]*casBlob)
+	addBlob := func(contents []byte) *repb.Digest {
+		digest := blobDigest(contents)
+		blobs[digest.GetHash()] = &casBlob{digest: digest, data: contents}
+
+		return digest
+	}
+
+	manifest, err := tsk.Manifest()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to checksum task: %w", err)
+	}
+
+	dependencies, err := json.Marshal(manifest.Dependencies)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode dependencies: %w", err)
+	}
+
+	// Input paths are recorded relative to the working directory, as in the checksum, so that
+	// the action may be shared between checkouts in different directories
+	inputs, err := json.Marshal(manifest.Inputs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode inputs: %w", err)
+	}
+
+	// Input files are named by position, since their paths may not be valid file node names
+	files := []*repb.FileNode{
+		{
+			Name:   "params.json",
+			Digest: addBlob(manifest.Params),
+		},
+		{
+			Name:   "dependencies.json",
+			Digest: addBlob(dependencies),
+		},
+		{
+			Name:   "inputs.json",
+			Digest: addBlob(inputs),
+		},
+	}
+
+	for idx, input := range manifest.Inputs {
+		file := tsk.Inputs[idx]
+
+		// The input was hashed for the manifest, so only its size is needed
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to stat input file %s: %w", file, err)
+		}
+
+		digest := &repb.Digest{
+			Hash:      hex.EncodeToString(input.Hash),
+			SizeBytes: info.Size(),
+		}
+		blobs[digest.GetHash()] = &casBlob{digest: digest, file: file}
+
+		files = append(files, &repb.FileNode{
+			Name:   fmt.Sprintf("input-%d", idx),
+			Digest: digest,
+		})
+	}
+
+	slices.SortFunc(files, func(a, b *repb.FileNode) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+
+	inputRoot, err := marshalBlob(&repb.Directory{Files: files})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	command, err := marshalBlob(&repb.Command{
+		Arguments: []string{"bonk", manifest.Backend, manifest.BackendVersion},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	action, err := marshalBlob(&repb.Action{
+		CommandDigest:   addBlob(command),
+		InputRootDigest: addBlob(inputRoot),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return addBlob(action), blobs, nil
+}
+
+func marshalBlob(msg proto.Message) ([]byte, error) {
+	blob, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", msg.ProtoReflect().Descriptor().Name(), err)
+	}
+
+	return blob, nil
+}
+
+func blobDigest(contents []byte) *repb.Digest {
+	hash := sha256.Sum256(contents)
+
+	return &repb.Digest{
+		Hash:      hex.EncodeToString(hash[:]),
+		SizeBytes: int64(len(contents)),
+	}
+}
+
+// Returns the digest of the file's contents, without reading it all into memory.
+func fileDigest(file string) (*repb.Digest, error) {
+	reader, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", file, err)
+	}
+	defer reader.Close()
+
+	hasher := sha256.New()
+
+	size, err := io.Copy(hasher, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash %s: %w", file, err)
+	}
+
+	return &repb.Digest{
+		Hash:      hex.EncodeToString(hasher.Sum(nil)),
+		SizeBytes: size,
+	}, nil
+}
+
+// Splits digests into batches small enough to transfer in one request, and the blobs which
+// are too large for any batch and must be streamed.
+func batchDigests(digests []*repb.Digest) ([][]*repb.Digest, []*repb.Digest) {
+	batches := [][]*repb.Digest{}
+	streamed := []*repb.Digest{}
+	batch := []*repb.Digest{}
+	batchSize := int64(0)
+
+	for _, digest := range digests {
+		if digest.GetSizeBytes() > maxBatchSize {
+			streamed = append(streamed, digest)
+
+			continue
+		}
+
+		if len(batch) > 0 && batchSize+digest.GetSizeBytes() > maxBatchSize {
+			batches = append(batches, batch)
+			batch = []*repb.Digest{}
+			batchSize = 0
+		}
+
+		batch = append(batch, digest)
+		batchSize += digest.GetSizeBytes()
+	}
+
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+
+	return batches, streamed
+}
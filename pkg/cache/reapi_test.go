@@ -0,0 +1,196 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package cache_test
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"cuelang.org/go/cue/cuecontext"
+
+	"go.bonk.build/pkg/cache"
+	"go.bonk.build/pkg/cache/internal/reapitest"
+	"go.bonk.build/pkg/task"
+)
+
+// Starts an in-memory REAPI server and returns a cache connected to it.
+func newTestREAPICache(t *testing.T) *cache.REAPICache {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer()
+	reapitest.NewServer().Register(server)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///reapitest",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect to test server: %v", err)
+	}
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return cache.NewREAPICache(conn, "test")
+}
+
+// Returns a task reading input.txt, creating its output directory.
+func newTestTask(t *testing.T) *task.Task {
+	t.Helper()
+
+	params := cuecontext.New().CompileString(`{message: "hello"}`)
+	tsk := task.New("test", "build", params, "input.txt")
+
+	err := os.MkdirAll(tsk.GetOutputDirectory(), 0o750)
+	if err != nil {
+		t.Fatalf("failed to create output directory: %v", err)
+	}
+
+	return &tsk
+}
+
+func writeFile(t *testing.T, file string, contents []byte) {
+	t.Helper()
+
+	err := os.MkdirAll(filepath.Dir(file), 0o750)
+	if err != nil {
+		t.Fatalf("failed to create %s: %v", filepath.Dir(file), err)
+	}
+
+	err = os.WriteFile(file, contents, 0o600)
+	if err != nil {
+		t.Fatalf("failed to write %s: %v", file, err)
+	}
+}
+
+func TestREAPICacheRoundTrip(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	rc := newTestREAPICache(t)
+
+	writeFile(t, "input.txt", []byte("input"))
+
+	// The large output exceeds the batch limit, so must be streamed in both directions
+	outputs := map[string][]byte{
+		"small.txt":        []byte("small"),
+		"empty.txt":        {},
+		"nested/large.bin": bytes.Repeat([]byte("0123456789abcdef"), reapitest.MaxBatchSize/8),
+	}
+
+	tsk := newTestTask(t)
+	for name, contents := range outputs {
+		writeFile(t, filepath.Join(tsk.GetOutputDirectory(), name), contents)
+	}
+
+	hit, err := rc.Fetch(t.Context(), tsk)
+	if err != nil {
+		t.Fatalf("failed to fetch before storing: %v", err)
+	}
+	if hit {
+		t.Fatal("fetched a task which was never stored")
+	}
+
+	err = rc.Store(t.Context(), tsk)
+	if err != nil {
+		t.Fatalf("failed to store task: %v", err)
+	}
+
+	err = os.RemoveAll(tsk.GetOutputDirectory())
+	if err != nil {
+		t.Fatalf("failed to remove outputs: %v", err)
+	}
+
+	tsk = newTestTask(t)
+
+	hit, err = rc.Fetch(t.Context(), tsk)
+	if err != nil {
+		t.Fatalf("failed to fetch task: %v", err)
+	}
+	if !hit {
+		t.Fatal("stored task was not fetched")
+	}
+
+	for name, want := range outputs {
+		got, err := os.ReadFile(filepath.Join(tsk.GetOutputDirectory(), name))
+		if err != nil {
+			t.Fatalf("failed to read restored output %s: %v", name, err)
+		}
+
+		if !bytes.Equal(got, want) {
+			t.Errorf("restored output %s has %d bytes, want %d", name, len(got), len(want))
+		}
+	}
+}
+
+func TestREAPICacheMissesChangedInputs(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	rc := newTestREAPICache(t)
+
+	writeFile(t, "input.txt", []byte("before"))
+
+	tsk := newTestTask(t)
+	writeFile(t, filepath.Join(tsk.GetOutputDirectory(), "out.txt"), []byte("output"))
+
+	err := rc.Store(t.Context(), tsk)
+	if err != nil {
+		t.Fatalf("failed to store task: %v", err)
+	}
+
+	writeFile(t, "input.txt", []byte("after"))
+
+	hit, err := rc.Fetch(t.Context(), newTestTask(t))
+	if err != nil {
+		t.Fatalf("failed to fetch task: %v", err)
+	}
+	if hit {
+		t.Error("fetched outputs stored for different inputs")
+	}
+}
+
+func TestREAPICacheMissesRenamedInputs(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	rc := newTestREAPICache(t)
+
+	writeFile(t, "input.txt", []byte("input"))
+	writeFile(t, "renamed.txt", []byte("input"))
+
+	tsk := newTestTask(t)
+	writeFile(t, filepath.Join(tsk.GetOutputDirectory(), "out.txt"), []byte("output"))
+
+	err := rc.Store(t.Context(), tsk)
+	if err != nil {
+		t.Fatalf("failed to store task: %v", err)
+	}
+
+	renamed := newTestTask(t)
+	renamed.Inputs = []string{"renamed.txt"}
+
+	hit, err := rc.Fetch(t.Context(), renamed)
+	if err != nil {
+		t.Fatalf("failed to fetch task: %v", err)
+	}
+	if hit {
+		t.Error("fetched outputs stored for differently named inputs")
+	}
+}
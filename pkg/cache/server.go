@@ -0,0 +1,124 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package cache // import "go.bonk.build/pkg/cache"
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+const archiveContentType = "application/gzip"
+
+// An http.Handler serving cache entries to HTTPCache clients from a local directory.
+type Server struct {
+	dir string
+}
+
+func NewServer(dir string) (*Server, error) {
+	err := os.MkdirAll(dir, 0o750)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return &Server{
+		dir: dir,
+	}, nil
+}
+
+func (srv *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	key := path.Base(req.URL.Path)
+
+	_, err := hex.DecodeString(key)
+	if err != nil || len(key) < 2 {
+		http.Error(w, "invalid digest", http.StatusBadRequest)
+
+		return
+	}
+
+	blob := filepath.Join(srv.dir, key[:2], key+".tar.gz")
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		srv.serveEntry(w, req, blob)
+	case http.MethodPut:
+		srv.storeEntry(w, req, blob)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (srv *Server) serveEntry(w http.ResponseWriter, req *http.Request, blob string) {
+	file, err := os.Open(blob)
+	if errors.Is(err, fs.ErrNotExist) {
+		http.NotFound(w, req)
+
+		return
+	} else if err != nil {
+		slog.ErrorContext(req.Context(), "failed to open cache entry", "entry", blob, "error", err)
+		http.Error(w, "failed to open cache entry", http.StatusInternalServerError)
+
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		http.Error(w, "failed to stat cache entry", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", archiveContentType)
+	http.ServeContent(w, req, "", info.ModTime(), file)
+}
+
+func (srv *Server) storeEntry(w http.ResponseWriter, req *http.Request, blob string) {
+	err := os.MkdirAll(filepath.Dir(blob), 0o750)
+	if err != nil {
+		slog.ErrorContext(req.Context(), "failed to create cache shard", "error", err)
+		http.Error(w, "failed to store cache entry", http.StatusInternalServerError)
+
+		return
+	}
+
+	// Write to a temporary file first so readers never see a partial entry
+	tmpFile, err := os.CreateTemp(filepath.Dir(blob), ".tmp-")
+	if err != nil {
+		slog.ErrorContext(req.Context(), "failed to create cache entry", "error", err)
+		http.Error(w, "failed to store cache entry", http.StatusInternalServerError)
+
+		return
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = io.Copy(tmpFile, req.Body)
+	if err == nil {
+		err = tmpFile.Close()
+	} else {
+		_ = tmpFile.Close()
+	}
+
+	if err == nil {
+		err = os.Rename(tmpFile.Name(), blob)
+	}
+
+	if err != nil {
+		slog.ErrorContext(req.Context(), "failed to store cache entry", "entry", blob, "error", err)
+		http.Error(w, "failed to store cache entry", http.StatusInternalServerError)
+
+		return
+	}
+
+	slog.DebugContext(req.Context(), "stored cache entry", "entry", blob)
+	w.WriteHeader(http.StatusCreated)
+}
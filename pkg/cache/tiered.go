@@ -0,0 +1,77 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package cache // import "go.bonk.build/pkg/cache"
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"go.bonk.build/pkg/task"
+)
+
+// A cache which consults several caches in order, such as a local cache followed by a remote.
+type TieredCache struct {
+	tiers []Cache
+}
+
+func NewTieredCache(tiers ...Cache) *TieredCache {
+	return &TieredCache{
+		tiers: tiers,
+	}
+}
+
+// Fetches from the first tier which holds the task, then backfills the tiers before it.
+// Errors from individual tiers are logged and treated as misses.
+func (tc *TieredCache) Fetch(ctx context.Context, tsk *task.Task) (bool, error) {
+	for idx, tier := range tc.tiers {
+		hit, err := tier.Fetch(ctx, tsk)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to fetch from cache tier", "tier", idx, "error", err)
+
+			continue
+		}
+
+		if !hit {
+			continue
+		}
+
+		for _, earlier := range tc.tiers[:idx] {
+			err = earlier.Store(ctx, tsk)
+			if err != nil {
+				slog.WarnContext(ctx, "failed to backfill cache tier", "error", err)
+			}
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// Stores the task's outputs in every tier.
+func (tc *TieredCache) Store(ctx context.Context, tsk *task.Task) error {
+	errs := []error{}
+
+	for _, tier := range tc.tiers {
+		errs = append(errs, tier.Store(ctx, tsk))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Closes each tier which holds a connection, such as a REAPICache.
+func (tc *TieredCache) Close() error {
+	errs := []error{}
+
+	for _, tier := range tc.tiers {
+		closer, ok := tier.(io.Closer)
+		if ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+
+	return errors.Join(errs...)
+}
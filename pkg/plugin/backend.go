@@ -6,11 +6,13 @@ package plugin // import "go.bonk.build/pkg/plugin"
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"cuelang.org/go/cue"
 
+	plugin "go.bonk.build/api/go"
 	bonkv0 "go.bonk.build/api/go/proto/bonk/v0"
 	"go.bonk.build/pkg/task"
 )
@@ -25,29 +27,89 @@ func (pb *PluginBackend) Outputs() []string {
 	return pb.descriptor.GetOutputs()
 }
 
-func (pb *PluginBackend) Execute(ctx context.Context, cuectx *cue.Context, tsk task.Task) error {
+// Returns the version reported by the plugin, or its build digest for local builds, which all
+// report a version of (devel).
+func (pb *PluginBackend) Version() string {
+	if pb.plugin.buildDigest != "" {
+		return pb.plugin.buildDigest
+	}
+
+	return pb.plugin.version
+}
+
+func (pb *PluginBackend) Execute(
+	ctx context.Context,
+	cuectx *cue.Context,
+	tsk task.Task,
+) (*task.Result, error) {
+	// Pass inputs the way the checksum records them, so that outputs cached for one checkout
+	// never refer to files in another
+	inputs := make([]string, len(tsk.Inputs))
+	for idx, input := range tsk.Inputs {
+		inputs[idx] = filepath.FromSlash(task.PortablePath(input))
+	}
+
 	outDir := tsk.GetOutputDirectory()
 	taskReqBuilder := bonkv0.PerformTaskRequest_builder{
 		Backend:      &pb.name,
-		Inputs:       tsk.Inputs,
+		Inputs:       inputs,
 		Parameters:   &structpb.Struct{},
 		OutDirectory: &outDir,
+		Dependencies: make(map[string]*bonkv0.TaskResult, len(tsk.Dependencies)),
 	}
 
 	err := tsk.Params.Decode(taskReqBuilder.Parameters)
 	if err != nil {
-		return fmt.Errorf("failed to encode parameters as protobuf: %w", err)
+		return nil, fmt.Errorf("failed to encode parameters as protobuf: %w", err)
 	}
 
-	_, err = pb.plugin.client.PerformTask(ctx, taskReqBuilder.Build())
-	if err != nil {
-		return fmt.Errorf("failed to call perform task: %w", err)
+	for _, dep := range tsk.Dependencies {
+		depResult, err := dep.LoadResult()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load result of dependency %s: %w", dep.String(), err)
+		}
+
+		taskReqBuilder.Dependencies[dep.Name()] = resultToProto(depResult)
 	}
 
-	err = tsk.SaveChecksum()
+	resp, err := pb.plugin.client.PerformTask(ctx, taskReqBuilder.Build())
 	if err != nil {
-		return fmt.Errorf("failed to checksum task: %w", err)
+		return nil, fmt.Errorf("failed to call perform task: %w", err)
+	}
+
+	return resultFromProto(resp.GetResult()), nil
+}
+
+func resultToProto(result *task.Result) *bonkv0.TaskResult {
+	diagnostics := make([]plugin.Diagnostic, len(result.Diagnostics))
+	for idx, diag := range result.Diagnostics {
+		diagnostics[idx] = plugin.Diagnostic{
+			Severity: diag.Severity,
+			Message:  diag.Message,
+		}
 	}
 
-	return nil
+	return (&plugin.TaskResult{
+		Outputs:     result.Outputs,
+		Diagnostics: diagnostics,
+		Values:      result.Values,
+	}).ToProto()
+}
+
+func resultFromProto(resultProto *bonkv0.TaskResult) *task.Result {
+	result := plugin.TaskResultFromProto(resultProto)
+
+	diagnostics := make([]task.Diagnostic, len(result.Diagnostics))
+	for idx, diag := range result.Diagnostics {
+		diagnostics[idx] = task.Diagnostic{
+			Severity: diag.Severity,
+			Message:  diag.Message,
+		}
+	}
+
+	return &task.Result{
+		Outputs:     result.Outputs,
+		Diagnostics: diagnostics,
+		Values:      result.Values,
+	}
 }
@@ -0,0 +1,60 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package plugin // import "go.bonk.build/pkg/plugin"
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"cuelang.org/go/cue"
+
+	bonkv0 "go.bonk.build/api/go/proto/bonk/v0"
+	"go.bonk.build/pkg/project"
+)
+
+type PluginFrontend struct {
+	plugin *Plugin
+	name   string
+}
+
+func (pf *PluginFrontend) GenerateTasks(
+	ctx context.Context,
+	cuectx *cue.Context,
+	config cue.Value,
+) ([]project.Decl, error) {
+	reqBuilder := bonkv0.GenerateTasksRequest_builder{
+		Frontend:      &pf.name,
+		Configuration: &structpb.Struct{},
+	}
+
+	err := config.Decode(reqBuilder.Configuration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode configuration as protobuf: %w", err)
+	}
+
+	resp, err := pf.plugin.client.GenerateTasks(ctx, reqBuilder.Build())
+	if err != nil {
+		return nil, fmt.Errorf("failed to call generate tasks: %w", err)
+	}
+
+	decls := make([]project.Decl, len(resp.GetTasks()))
+	for idx, desc := range resp.GetTasks() {
+		inputs := make([]project.Input, len(desc.GetInputs()))
+		for inputIdx, input := range desc.GetInputs() {
+			inputs[inputIdx] = project.Input{Path: input}
+		}
+
+		decls[idx] = project.Decl{
+			ID:           desc.GetId(),
+			Backend:      desc.GetBackend(),
+			Params:       cuectx.Encode(desc.GetParameters()),
+			Inputs:       inputs,
+			Dependencies: desc.GetDependencies(),
+		}
+	}
+
+	return decls, nil
+}
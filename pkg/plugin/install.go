@@ -0,0 +1,98 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package plugin // import "go.bonk.build/pkg/plugin"
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Returns the location of the prebuilt binary for a plugin reference within pluginDir, named
+// the same way go install names it.
+func BinaryPath(pluginDir, pluginRef string) string {
+	pluginPath, _ := ParseReference(pluginRef)
+
+	return filepath.Join(pluginDir, filepath.FromSlash(pluginRef), executableName(pluginPath))
+}
+
+// Returns the name go install gives the binary of the package at pluginPath: the last path
+// element, or the one before it if the last is a major version suffix such as v2, which
+// is never v1.
+func executableName(pluginPath string) string {
+	dir, name := path.Split(pluginPath)
+	if dir != "" && isMajorVersion(name) {
+		return path.Base(dir)
+	}
+
+	return name
+}
+
+func isMajorVersion(elem string) bool {
+	digits, ok := strings.CutPrefix(elem, "v")
+	if !ok || digits == "" || digits == "1" || digits[0] == '0' {
+		return false
+	}
+
+	return strings.Trim(digits, "0123456789") == ""
+}
+
+// Compiles the referenced plugin and caches the binary in pluginDir. Only plugins with a
+// version may be installed, since the source of unversioned plugins may change at any time.
+func Install(ctx context.Context, pluginDir, pluginRef string) (string, error) {
+	_, version := ParseReference(pluginRef)
+	if version == "" {
+		return "", fmt.Errorf(
+			"plugin %s has no version, so it can't be installed and is run from source instead",
+			pluginRef,
+		)
+	}
+
+	binPath := BinaryPath(pluginDir, pluginRef)
+	binDir := filepath.Dir(binPath)
+
+	err := os.MkdirAll(binDir, 0o750)
+	if err != nil {
+		return "", fmt.Errorf("failed to create plugin directory: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, "go", "install", pluginRef)
+	cmd.Env = append(os.Environ(), "GOBIN="+binDir)
+
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+
+	slog.DebugContext(ctx, "installing plugin", "plugin", pluginRef, "args", cmd.Args)
+
+	err = cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("failed to compile plugin %s: %w", pluginRef, err)
+	}
+
+	return binPath, nil
+}
+
+// Returns the command used to launch a plugin, preferring an installed binary over go run.
+// Plugins without a version are always run from source, so that edits to them take effect.
+func pluginCommand(ctx context.Context, pluginDir, pluginRef string) *exec.Cmd {
+	_, version := ParseReference(pluginRef)
+
+	if pluginDir != "" && version != "" {
+		binPath := BinaryPath(pluginDir, pluginRef)
+
+		stat, err := os.Stat(binPath)
+		if err == nil && !stat.IsDir() {
+			return exec.CommandContext(ctx, binPath)
+		}
+
+		slog.DebugContext(ctx, "plugin binary not installed", "plugin", pluginRef, "path", binPath)
+	}
+
+	return exec.CommandContext(ctx, "go", "run", pluginRef)
+}
@@ -8,8 +8,11 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"os/exec"
 	"path"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
 
 	"github.com/ValerySidorin/shclog"
 
@@ -18,6 +21,7 @@ import (
 	plugin "go.bonk.build/api/go"
 	bonkv0 "go.bonk.build/api/go/proto/bonk/v0"
 	"go.bonk.build/pkg/backend"
+	"go.bonk.build/pkg/project"
 )
 
 type BackendRegistrar interface {
@@ -26,28 +30,54 @@ type BackendRegistrar interface {
 }
 
 type PluginManager struct {
-	plugins map[string]*Plugin
+	cuectx    *cue.Context
+	pluginDir string
+	plugins   map[string]*Plugin
+	frontends map[string]*PluginFrontend
+
+	// The processes of the running plugins, keyed by module path.
+	processes map[string]*goplugin.Client
 
 	backend BackendRegistrar
 }
 
-func NewPluginManager(backend BackendRegistrar) *PluginManager {
+// Creates a plugin manager which launches installed plugin binaries from pluginDir,
+// falling back to go run for plugins which are not installed.
+func NewPluginManager(backend BackendRegistrar, pluginDir string) *PluginManager {
 	pm := &PluginManager{}
+	pm.cuectx = cuecontext.New()
+	pm.pluginDir = pluginDir
 	pm.plugins = make(map[string]*Plugin)
+	pm.processes = make(map[string]*goplugin.Client)
+	pm.frontends = make(map[string]*PluginFrontend)
 	pm.backend = backend
 
 	return pm
 }
 
-func (pm *PluginManager) StartPlugin(ctx context.Context, pluginPath string) error {
+// Splits a plugin reference of the form module/path[@version] into its path and version.
+func ParseReference(ref string) (string, string) {
+	pluginPath, version, _ := strings.Cut(ref, "@")
+
+	return pluginPath, version
+}
+
+// Starts the plugin referenced by module/path[@version].
+func (pm *PluginManager) StartPlugin(ctx context.Context, pluginRef string) error {
+	pluginPath, _ := ParseReference(pluginRef)
 	pluginName := path.Base(pluginPath)
 
+	_, exists := pm.plugins[pluginName]
+	if exists {
+		return fmt.Errorf("duplicate plugin name: %s", pluginName)
+	}
+
 	process := goplugin.NewClient(&goplugin.ClientConfig{
 		HandshakeConfig: plugin.Handshake,
 		Plugins: map[string]goplugin.Plugin{
 			plugin.PluginType: &bonkPluginClient{},
 		},
-		Cmd:     exec.CommandContext(ctx, "go", "run", pluginPath),
+		Cmd:     pluginCommand(ctx, pm.pluginDir, pluginRef),
 		Managed: true,
 		AllowedProtocols: []goplugin.Protocol{
 			goplugin.ProtocolGRPC,
@@ -76,6 +106,7 @@ func (pm *PluginManager) StartPlugin(ctx context.Context, pluginPath string) err
 	}
 
 	pm.plugins[pluginName] = plug
+	pm.processes[pluginPath] = process
 
 	for backendName, backend := range plug.backends {
 		err = pm.backend.RegisterBackend(fmt.Sprintf("%s:%s", pluginName, backendName), &backend)
@@ -84,9 +115,52 @@ func (pm *PluginManager) StartPlugin(ctx context.Context, pluginPath string) err
 		}
 	}
 
+	for frontendName, frontend := range plug.frontends {
+		pm.frontends[fmt.Sprintf("%s:%s", pluginName, frontendName)] = &frontend
+	}
+
 	return nil
 }
 
+// Stops the plugin with the given module path, unregistering its backends and frontends.
+// Does nothing if the plugin isn't running.
+func (pm *PluginManager) StopPlugin(pluginPath string) {
+	process, ok := pm.processes[pluginPath]
+	if !ok {
+		return
+	}
+
+	pluginName := path.Base(pluginPath)
+	plug := pm.plugins[pluginName]
+
+	for backendName := range plug.backends {
+		pm.backend.UnregisterBackend(fmt.Sprintf("%s:%s", pluginName, backendName))
+	}
+
+	for frontendName := range plug.frontends {
+		delete(pm.frontends, fmt.Sprintf("%s:%s", pluginName, frontendName))
+	}
+
+	process.Kill()
+
+	delete(pm.plugins, pluginName)
+	delete(pm.processes, pluginPath)
+}
+
+// Expands the configuration of a plugin:Frontend into task declarations.
+func (pm *PluginManager) GenerateTasks(
+	ctx context.Context,
+	frontendName string,
+	config cue.Value,
+) ([]project.Decl, error) {
+	frontend, ok := pm.frontends[frontendName]
+	if !ok {
+		return nil, fmt.Errorf("frontend %s not found", frontendName)
+	}
+
+	return frontend.GenerateTasks(ctx, pm.cuectx, config)
+}
+
 func (pm *PluginManager) Shutdown() {
 	for pluginName, plugin := range pm.plugins {
 		for backendName := range plugin.backends {
@@ -94,6 +168,8 @@ func (pm *PluginManager) Shutdown() {
 		}
 	}
 	pm.plugins = make(map[string]*Plugin)
+	pm.processes = make(map[string]*goplugin.Client)
+	pm.frontends = make(map[string]*PluginFrontend)
 
 	goplugin.CleanupClients()
 }
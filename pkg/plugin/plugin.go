@@ -17,8 +17,11 @@ import (
 )
 
 type Plugin struct {
-	client   bonkv0.BonkPluginServiceClient
-	backends map[string]PluginBackend
+	client      bonkv0.BonkPluginServiceClient
+	version     string
+	buildDigest string
+	backends    map[string]PluginBackend
+	frontends   map[string]PluginFrontend
 }
 
 func NewPlugin(ctx context.Context, client bonkv0.BonkPluginServiceClient) (*Plugin, error) {
@@ -30,10 +33,18 @@ func NewPlugin(ctx context.Context, client bonkv0.BonkPluginServiceClient) (*Plu
 	}
 
 	plugin := &Plugin{
-		client:   client,
-		backends: make(map[string]PluginBackend, len(resp.GetBackends())),
+		client:      client,
+		version:     resp.GetVersion(),
+		buildDigest: resp.GetBuildDigest(),
+		backends:    make(map[string]PluginBackend, len(resp.GetBackends())),
+		frontends:   make(map[string]PluginFrontend, len(resp.GetFrontends())),
 	}
 
+	slog.DebugContext(ctx, "configured plugin",
+		"version", plugin.version,
+		"build-digest", plugin.buildDigest,
+	)
+
 	for name, backendDesc := range resp.GetBackends() {
 		_, existed := plugin.backends[name]
 		if existed {
@@ -47,6 +58,13 @@ func NewPlugin(ctx context.Context, client bonkv0.BonkPluginServiceClient) (*Plu
 		}
 	}
 
+	for name := range resp.GetFrontends() {
+		plugin.frontends[name] = PluginFrontend{
+			name:   name,
+			plugin: plugin,
+		}
+	}
+
 	return plugin, nil
 }
 
@@ -0,0 +1,184 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package profile // import "go.bonk.build/pkg/profile"
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// The phases of a task which are recorded. The task phase spans the others, from when the
+// task is sent to its backend until it finishes.
+const (
+	PhaseQueued     = "queued"
+	PhaseTask       = "task"
+	PhaseChecksum   = "checksum"
+	PhaseCacheFetch = "cache fetch"
+	PhaseExecute    = "execute"
+	PhaseCacheStore = "cache store"
+)
+
+// A phase of a task, such as waiting in the queue, checksumming, or executing.
+type Span struct {
+	Task  string
+	Phase string
+	Start time.Time
+	End   time.Time
+	Args  map[string]string
+}
+
+// Records how long each task spends in each phase of a build. A nil profile records nothing,
+// so that callers needn't check whether profiling is enabled.
+type Profile struct {
+	start time.Time
+	mutex sync.Mutex
+	spans []Span
+}
+
+func New() *Profile {
+	return &Profile{
+		start: time.Now(),
+	}
+}
+
+// Records that the task spent the time from start until now in phase, along with any args
+// describing it.
+func (p *Profile) Record(taskName, phase string, start time.Time, args map[string]string) {
+	if p == nil {
+		return
+	}
+
+	end := time.Now()
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.spans = append(p.spans, Span{
+		Task:  taskName,
+		Phase: phase,
+		Start: start,
+		End:   end,
+		Args:  args,
+	})
+}
+
+// Returns the recorded spans, in the order they finished.
+func (p *Profile) Spans() []Span {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return slices.Clone(p.spans)
+}
+
+type traceEvent struct {
+	Name      string            `json:"name"`
+	Category  string            `json:"cat,omitempty"`
+	Phase     string            `json:"ph"`
+	Timestamp int64             `json:"ts"`
+	Duration  int64             `json:"dur,omitempty"`
+	Process   int               `json:"pid"`
+	Thread    int               `json:"tid"`
+	Args      map[string]string `json:"args,omitempty"`
+}
+
+type trace struct {
+	TraceEvents     []traceEvent `json:"traceEvents"`
+	DisplayTimeUnit string       `json:"displayTimeUnit"`
+}
+
+// Writes the profile in the Chrome trace event format, which can be viewed with Perfetto or
+// chrome://tracing. Each task is drawn on a lane of its own while it runs, with its phases
+// nested beneath it.
+func (p *Profile) WriteTrace(w io.Writer) error {
+	spans := p.Spans()
+
+	// Find the extent of each task, so that tasks which overlap are drawn on separate lanes
+	type extent struct {
+		task       string
+		start, end time.Time
+	}
+
+	extents := []*extent{}
+	byTask := make(map[string]*extent)
+
+	for _, span := range spans {
+		ext, ok := byTask[span.Task]
+		if !ok {
+			ext = &extent{task: span.Task, start: span.Start, end: span.End}
+			byTask[span.Task] = ext
+			extents = append(extents, ext)
+		}
+
+		if span.Start.Before(ext.start) {
+			ext.start = span.Start
+		}
+
+		if span.End.After(ext.end) {
+			ext.end = span.End
+		}
+	}
+
+	slices.SortStableFunc(extents, func(a, b *extent) int {
+		return a.start.Compare(b.start)
+	})
+
+	// Place each task on the first lane which is free when it starts
+	laneEnds := []time.Time{}
+	lanes := make(map[string]int, len(extents))
+
+	for _, ext := range extents {
+		lane := slices.IndexFunc(laneEnds, func(end time.Time) bool {
+			return !end.After(ext.start)
+		})
+		if lane < 0 {
+			lane = len(laneEnds)
+			laneEnds = append(laneEnds, time.Time{})
+		}
+
+		laneEnds[lane] = ext.end
+		lanes[ext.task] = lane + 1
+	}
+
+	out := trace{
+		TraceEvents:     make([]traceEvent, 0, len(spans)),
+		DisplayTimeUnit: "ms",
+	}
+
+	for _, span := range spans {
+		name := span.Phase
+		if span.Phase == PhaseTask {
+			name = span.Task
+		}
+
+		args := maps.Clone(span.Args)
+		if args == nil {
+			args = make(map[string]string, 1)
+		}
+
+		args["task"] = span.Task
+
+		out.TraceEvents = append(out.TraceEvents, traceEvent{
+			Name:      name,
+			Category:  "bonk",
+			Phase:     "X",
+			Timestamp: span.Start.Sub(p.start).Microseconds(),
+			Duration:  span.End.Sub(span.Start).Microseconds(),
+			Process:   1,
+			Thread:    lanes[span.Task],
+			Args:      args,
+		})
+	}
+
+	err := json.NewEncoder(w).Encode(out)
+	if err != nil {
+		return fmt.Errorf("failed to encode trace: %w", err)
+	}
+
+	return nil
+}
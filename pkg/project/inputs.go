@@ -0,0 +1,155 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package project // import "go.bonk.build/pkg/project"
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// A pattern matching input files, along with patterns for files to leave out.
+type Glob struct {
+	Glob    string   `json:"glob"`
+	Exclude []string `json:"exclude"`
+}
+
+// Expands a path or glob pattern into the files it matches, recursing into directories in
+// lexical order. Relative patterns are resolved against the project directory, and files
+// matching any of excludes are left out. Paths which don't exist are returned as is, so that
+// hashing reports them.
+//
+// Also returns the directory beneath which any file the pattern may match lies, or an empty
+// string if the pattern names a single file.
+func (p *Project) expandInput(pattern string, excludes []string) ([]string, string, error) {
+	for _, exclude := range excludes {
+		if !doublestar.ValidatePattern(exclude) {
+			return nil, "", fmt.Errorf("invalid exclude pattern %s", exclude)
+		}
+	}
+
+	expander := inputExpander{
+		projectDir: p.Dir,
+		excludes:   excludes,
+		seen:       make(map[string]bool),
+	}
+
+	if !hasMeta(filepath.ToSlash(pattern)) {
+		file := p.resolvePath(pattern)
+
+		err := expander.add(file)
+		if errors.Is(err, fs.ErrNotExist) {
+			return []string{file}, "", nil
+		} else if err != nil {
+			return nil, "", err
+		}
+
+		info, err := os.Stat(file)
+		if err != nil || !info.IsDir() {
+			return expander.files, "", nil
+		}
+
+		return expander.files, file, nil
+	}
+
+	// Glob beneath the pattern's literal leading directories, which may lie outside the project
+	prefix, rest := splitGlob(filepath.ToSlash(pattern))
+	if prefix == "" && filepath.IsAbs(pattern) {
+		prefix = "/"
+	}
+
+	root := p.resolvePath(filepath.FromSlash(prefix))
+
+	matches, err := doublestar.Glob(os.DirFS(root), rest)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid input pattern %s: %w", pattern, err)
+	}
+
+	slices.Sort(matches)
+
+	for _, match := range matches {
+		err = expander.add(filepath.Join(root, filepath.FromSlash(match)))
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return expander.files, root, nil
+}
+
+// Resolves the path against the project directory, unless it's absolute.
+func (p *Project) resolvePath(file string) string {
+	if filepath.IsAbs(file) {
+		return filepath.Clean(file)
+	}
+
+	return filepath.Join(p.Dir, file)
+}
+
+// Splits the pattern into its leading segments which contain no glob metacharacters, which
+// name the directory every match lies beneath, and the remainder of the pattern.
+func splitGlob(pattern string) (string, string) {
+	segments := strings.Split(pattern, "/")
+	literal := slices.IndexFunc(segments, hasMeta)
+
+	return strings.Join(segments[:literal], "/"), strings.Join(segments[literal:], "/")
+}
+
+type inputExpander struct {
+	projectDir string
+	excludes   []string
+	files      []string
+	seen       map[string]bool
+}
+
+// Adds the file, or every file beneath it if it's a directory.
+func (ie *inputExpander) add(file string) error {
+	return filepath.WalkDir(file, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if ie.excluded(file) {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		if !entry.IsDir() && !ie.seen[file] {
+			ie.seen[file] = true
+			ie.files = append(ie.files, file)
+		}
+
+		return nil
+	})
+}
+
+// Reports whether the file matches any of the exclude patterns, which are relative to the
+// project directory.
+func (ie *inputExpander) excluded(file string) bool {
+	relPath, err := filepath.Rel(ie.projectDir, file)
+	if err != nil {
+		return false
+	}
+
+	for _, exclude := range ie.excludes {
+		if doublestar.MatchUnvalidated(exclude, filepath.ToSlash(relPath)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasMeta(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[{")
+}
@@ -0,0 +1,158 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestExpandInput(t *testing.T) {
+	root := t.TempDir()
+	projectDir := filepath.Join(root, "project")
+
+	for _, file := range []string{
+		"README.md",
+		"project/src/a.txt",
+		"project/src/sub/b.txt",
+		"project/docs/c.txt",
+		"project/docs/d.md",
+		"project/skip/e.txt",
+	} {
+		file = filepath.Join(root, file)
+
+		err := os.MkdirAll(filepath.Dir(file), 0o750)
+		if err != nil {
+			t.Fatalf("failed to create %s: %v", filepath.Dir(file), err)
+		}
+
+		err = os.WriteFile(file, []byte(file), 0o600)
+		if err != nil {
+			t.Fatalf("failed to write %s: %v", file, err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		pattern  string
+		excludes []string
+		files    []string
+		dir      string
+	}{
+		{
+			name:    "file",
+			pattern: "src/a.txt",
+			files:   []string{"project/src/a.txt"},
+		},
+		{
+			name:    "parent file",
+			pattern: "../README.md",
+			files:   []string{"README.md"},
+		},
+		{
+			name:    "absolute file",
+			pattern: filepath.Join(root, "README.md"),
+			files:   []string{"README.md"},
+		},
+		{
+			name:    "missing file",
+			pattern: "missing.txt",
+			files:   []string{"project/missing.txt"},
+		},
+		{
+			name:    "directory",
+			pattern: "src",
+			files:   []string{"project/src/a.txt", "project/src/sub/b.txt"},
+			dir:     "project/src",
+		},
+		{
+			name:     "directory with excludes",
+			pattern:  ".",
+			excludes: []string{"skip", "**/*.md"},
+			files: []string{
+				"project/docs/c.txt",
+				"project/src/a.txt",
+				"project/src/sub/b.txt",
+			},
+			dir: "project",
+		},
+		{
+			name:    "glob",
+			pattern: "**/*.txt",
+			files: []string{
+				"project/docs/c.txt",
+				"project/skip/e.txt",
+				"project/src/a.txt",
+				"project/src/sub/b.txt",
+			},
+			dir: "project",
+		},
+		{
+			name:     "glob with excludes",
+			pattern:  "**/*.txt",
+			excludes: []string{"skip/**", "src/sub/*"},
+			files:    []string{"project/docs/c.txt", "project/src/a.txt"},
+			dir:      "project",
+		},
+		{
+			name:    "nested glob",
+			pattern: "src/**/*.txt",
+			files:   []string{"project/src/a.txt", "project/src/sub/b.txt"},
+			dir:     "project/src",
+		},
+		{
+			name:    "parent glob",
+			pattern: "../*.md",
+			files:   []string{"README.md"},
+			dir:     ".",
+		},
+		{
+			name:     "parent glob with excludes",
+			pattern:  "../**/*.md",
+			excludes: []string{"../README.md"},
+			files:    []string{"project/docs/d.md"},
+			dir:      ".",
+		},
+	}
+
+	proj := Project{Dir: projectDir}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			files, dir, err := proj.expandInput(test.pattern, test.excludes)
+			if err != nil {
+				t.Fatalf("failed to expand %s: %v", test.pattern, err)
+			}
+
+			want := make([]string, len(test.files))
+			for i, file := range test.files {
+				want[i] = filepath.Join(root, file)
+			}
+
+			if !slices.Equal(files, want) {
+				t.Errorf("expanded to %v, want %v", files, want)
+			}
+
+			wantDir := ""
+			if test.dir != "" {
+				wantDir = filepath.Join(root, test.dir)
+			}
+
+			if dir != wantDir {
+				t.Errorf("input directory is %q, want %q", dir, wantDir)
+			}
+		})
+	}
+}
+
+func TestExpandInputInvalidExclude(t *testing.T) {
+	proj := Project{Dir: t.TempDir()}
+
+	_, _, err := proj.expandInput(".", []string{"[unclosed"})
+	if err == nil {
+		t.Error("expanded input with an invalid exclude pattern")
+	}
+}
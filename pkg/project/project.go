@@ -0,0 +1,332 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package project // import "go.bonk.build/pkg/project"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/load"
+
+	_ "embed"
+
+	"go.bonk.build/pkg/task"
+)
+
+//go:embed schema.cue
+var schemaSource string
+
+// The declaration of a task, as written in the project or returned by a frontend.
+type Decl struct {
+	ID           string    `json:"id"`
+	Backend      string    `json:"backend"`
+	Params       cue.Value `json:"-"`
+	Inputs       []Input   `json:"inputs"`
+	Dependencies []string  `json:"dependencies"`
+}
+
+// A reference to a file declared as an output by the backend of another task.
+type OutputRef struct {
+	Task   string `json:"task"`
+	Output string `json:"output"`
+}
+
+// An input to a task: a path or glob pattern relative to the project directory, a glob with
+// exclusions, or the output of another task.
+type Input struct {
+	Path   string
+	Glob   *Glob
+	Output *OutputRef
+}
+
+func (in *Input) UnmarshalJSON(data []byte) error {
+	err := json.Unmarshal(data, &in.Path)
+	if err == nil {
+		return nil
+	}
+
+	fields := make(map[string]json.RawMessage)
+
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return fmt.Errorf("input must be a path, a glob, or an output reference: %w", err)
+	}
+
+	_, isGlob := fields["glob"]
+	if isGlob {
+		in.Glob = &Glob{}
+		err = json.Unmarshal(data, in.Glob)
+	} else {
+		in.Output = &OutputRef{}
+		err = json.Unmarshal(data, in.Output)
+	}
+
+	if err != nil {
+		return fmt.Errorf("input must be a path, a glob, or an output reference: %w", err)
+	}
+
+	return nil
+}
+
+// Expands frontend configuration into task declarations.
+type Frontends interface {
+	GenerateTasks(ctx context.Context, frontend string, config cue.Value) ([]Decl, error)
+}
+
+// Describes the backends which execute tasks.
+type Backends interface {
+	Outputs(backend string) ([]string, error)
+	Version(backend string) (string, error)
+}
+
+// A loaded bonk project.
+type Project struct {
+	Dir   string
+	Value cue.Value
+
+	// The directories which each task's directory and glob inputs were expanded from, keyed by
+	// task id in the form id:backend. Filled in by Tasks.
+	InputDirs map[string][]string
+}
+
+// Loads the bonk project in dir and validates it against the project schema.
+func Load(cuectx *cue.Context, dir string) (*Project, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve project directory: %w", err)
+	}
+
+	insts := load.Instances([]string{"."}, &load.Config{Dir: absDir})
+	values, err := cuectx.BuildInstances(insts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build bonk project: %w", err)
+	}
+
+	// Unify all of the values into a single source of truth
+	value := cuectx.CompileString(schemaSource, cue.Filename("schema.cue")).
+		LookupPath(cue.MakePath(cue.Def("Project")))
+	for _, valuePart := range values {
+		value = value.Unify(valuePart)
+	}
+
+	err = value.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid bonk project: %w", err)
+	}
+
+	return &Project{
+		Dir:   absDir,
+		Value: value,
+	}, nil
+}
+
+// Returns the plugins required by the project, in the form module/path[@version].
+func (p *Project) Plugins() ([]string, error) {
+	plugins := []string{}
+
+	err := p.Value.LookupPath(cue.MakePath(cue.Str("plugins"))).Decode(&plugins)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode project plugins: %w", err)
+	}
+
+	return plugins, nil
+}
+
+// Collects the tasks declared by the project and its frontends, in the order they were declared.
+func (p *Project) Tasks(
+	ctx context.Context,
+	frontends Frontends,
+	backends Backends,
+) ([]task.Task, error) {
+	err := p.injectOutputs(backends)
+	if err != nil {
+		return nil, err
+	}
+
+	decls, err := p.declaredTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := p.Value.LookupPath(cue.MakePath(cue.Str("frontends"))).Fields()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project frontends: %w", err)
+	}
+
+	for iter.Next() {
+		frontendName := iter.Selector().Unquoted()
+
+		generated, err := frontends.GenerateTasks(ctx, frontendName, iter.Value())
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate tasks with frontend %s: %w", frontendName, err)
+		}
+
+		decls = append(decls, generated...)
+	}
+
+	return p.resolveTasks(decls, backends)
+}
+
+// Fills in tasks.<id>.outputs with a reference to each output declared by the task's backend,
+// so that other tasks may consume them as inputs.
+func (p *Project) injectOutputs(backends Backends) error {
+	iter, err := p.Value.LookupPath(cue.MakePath(cue.Str("tasks"))).Fields()
+	if err != nil {
+		return fmt.Errorf("failed to read project tasks: %w", err)
+	}
+
+	tasks := make(map[string]any)
+
+	for iter.Next() {
+		backend, err := iter.Value().LookupPath(cue.MakePath(cue.Str("backend"))).String()
+		if err != nil {
+			return fmt.Errorf("failed to read backend of task %s: %w", iter.Selector(), err)
+		}
+
+		declared, err := backends.Outputs(backend)
+		if err != nil {
+			return fmt.Errorf("failed to describe task %s: %w", iter.Selector(), err)
+		}
+
+		outputs := make(map[string]any, len(declared))
+		for _, output := range declared {
+			outputs[output] = map[string]any{}
+		}
+
+		tasks[iter.Selector().Unquoted()] = map[string]any{
+			"outputs": outputs,
+		}
+	}
+
+	p.Value = p.Value.Unify(p.Value.Context().Encode(map[string]any{
+		"tasks": tasks,
+	}))
+
+	return nil
+}
+
+func (p *Project) declaredTasks() ([]Decl, error) {
+	iter, err := p.Value.LookupPath(cue.MakePath(cue.Str("tasks"))).Fields()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project tasks: %w", err)
+	}
+
+	decls := []Decl{}
+
+	for iter.Next() {
+		decl := Decl{}
+		err = iter.Value().Decode(&decl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode task %s: %w", iter.Selector(), err)
+		}
+
+		decl.Params = iter.Value().LookupPath(cue.MakePath(cue.Str("params")))
+		decls = append(decls, decl)
+	}
+
+	return decls, nil
+}
+
+func (p *Project) resolveTasks(decls []Decl, backends Backends) ([]task.Task, error) {
+	declMap := make(map[string]Decl, len(decls))
+	tasks := make(map[string]task.Task, len(decls))
+	p.InputDirs = make(map[string][]string, len(decls))
+
+	for _, decl := range decls {
+		_, exists := declMap[decl.ID]
+		if exists {
+			return nil, fmt.Errorf("duplicate task id %s", decl.ID)
+		}
+
+		version, err := backends.Version(decl.Backend)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe task %s: %w", decl.ID, err)
+		}
+
+		tsk := task.New(decl.Backend, decl.ID, decl.Params)
+		tsk.BackendVersion = version
+
+		declMap[decl.ID] = decl
+		tasks[decl.ID] = tsk
+	}
+
+	// Resolve inputs, depending on any task whose outputs are consumed
+	for _, decl := range decls {
+		tsk := tasks[decl.ID]
+
+		for _, input := range decl.Inputs {
+			if input.Output == nil {
+				pattern, excludes := input.Path, []string(nil)
+				if input.Glob != nil {
+					pattern, excludes = input.Glob.Glob, input.Glob.Exclude
+				}
+
+				files, dir, err := p.expandInput(pattern, excludes)
+				if err != nil {
+					return nil, fmt.Errorf("failed to expand inputs of task %s: %w", decl.ID, err)
+				}
+
+				tsk.Inputs = append(tsk.Inputs, files...)
+
+				if dir != "" {
+					p.InputDirs[tsk.ID.String()] = append(p.InputDirs[tsk.ID.String()], dir)
+				}
+
+				continue
+			}
+
+			producer, ok := tasks[input.Output.Task]
+			if !ok {
+				return nil, fmt.Errorf(
+					"task %s consumes output of unknown task %s",
+					decl.ID,
+					input.Output.Task,
+				)
+			}
+
+			outputPath, err := filepath.Abs(
+				filepath.Join(producer.GetOutputDirectory(), input.Output.Output),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve output %s: %w", input.Output.Output, err)
+			}
+
+			tsk.Inputs = append(tsk.Inputs, outputPath)
+
+			if !slices.Contains(decl.Dependencies, input.Output.Task) {
+				decl.Dependencies = append(decl.Dependencies, input.Output.Task)
+				declMap[decl.ID] = decl
+			}
+		}
+
+		tasks[decl.ID] = tsk
+	}
+
+	// Link each task to its dependencies. The scheduler orders the tasks, and reports
+	// dependencies which weren't declared or which form cycles.
+	resolved := make([]task.Task, 0, len(decls))
+
+	for _, decl := range decls {
+		tsk := tasks[decl.ID]
+
+		for _, dep := range declMap[decl.ID].Dependencies {
+			depTask, ok := tasks[dep]
+			if !ok {
+				tsk.Dependencies = append(tsk.Dependencies, task.UndeclaredTaskId(dep))
+
+				continue
+			}
+
+			tsk.Dependencies = append(tsk.Dependencies, depTask.ID)
+		}
+
+		resolved = append(resolved, tsk)
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,56 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package project
+
+#Project: {
+	// The plugins required by the project, in the form module/path[@version].
+	plugins: [...string] | *[]
+
+	// The tasks to execute, keyed by their id.
+	tasks: [ID=string]: #Task & {
+		id: ID
+	}
+
+	// Configuration for plugin-provided frontends, keyed by plugin:Frontend name.
+	frontends: [string]: {...}
+}
+
+#Task: {
+	// The unique id of the task within the project.
+	id: string
+
+	// The backend to execute the task with, in the form plugin:Backend.
+	backend: string
+
+	// The parameters to pass to the backend.
+	params: {...} | *{}
+
+	// The files the task consumes: paths or glob patterns relative to the project directory,
+	// where directories include every file beneath them, globs with exclusions, or the outputs
+	// of other tasks, such as tasks.Other.outputs["file.yaml"].
+	inputs: [...(string | #Glob | #OutputRef)] | *[]
+
+	// The ids of the tasks which must complete before this one.
+	// Tasks whose outputs are consumed as inputs are added automatically.
+	dependencies: [...string] | *[]
+
+	// The outputs declared by the task's backend, filled in by bonk.
+	outputs: [Output=string]: #OutputRef & {
+		task:   id
+		output: Output
+	}
+}
+
+#Glob: {
+	// A pattern relative to the project directory, where ** matches any number of directories.
+	glob: string
+
+	// Patterns for files to leave out, relative to the project directory.
+	exclude: [...string] | *[]
+}
+
+#OutputRef: {
+	task:   string
+	output: string
+}
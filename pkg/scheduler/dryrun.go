@@ -0,0 +1,69 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package scheduler // import "go.bonk.build/pkg/scheduler"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.bonk.build/pkg/task"
+)
+
+// The predicted outcome of building a task.
+type Prediction struct {
+	Task string
+	// Why the task would execute. Empty if the task is up to date.
+	Reasons []string
+}
+
+// A TaskSender which, rather than executing tasks, predicts whether each one would execute.
+// Tasks are never executed and nothing is written to their output directories.
+type DryRun struct {
+	fileHashes  *task.FileHashes
+	mutex       sync.Mutex
+	predictions []Prediction
+	stale       map[string]bool
+}
+
+func NewDryRun(fileHashes *task.FileHashes) *DryRun {
+	return &DryRun{
+		fileHashes: fileHashes,
+		stale:      make(map[string]bool),
+	}
+}
+
+func (dr *DryRun) SendTask(_ context.Context, tsk task.Task) error {
+	if tsk.Hashes == nil {
+		tsk.Hashes = dr.fileHashes
+	}
+
+	reasons := tsk.Explain()
+
+	dr.mutex.Lock()
+	defer dr.mutex.Unlock()
+
+	// Dependencies finish first, so their predictions are already recorded
+	for _, dep := range tsk.Dependencies {
+		if dr.stale[dep.String()] {
+			reasons = append(reasons, fmt.Sprintf("dependency %s would execute", dep.String()))
+		}
+	}
+
+	dr.stale[tsk.ID.String()] = len(reasons) > 0
+	dr.predictions = append(dr.predictions, Prediction{
+		Task:    tsk.ID.String(),
+		Reasons: reasons,
+	})
+
+	return nil
+}
+
+// Returns the predictions for each task, in the order they were evaluated.
+func (dr *DryRun) Predictions() []Prediction {
+	dr.mutex.Lock()
+	defer dr.mutex.Unlock()
+
+	return append([]Prediction(nil), dr.predictions...)
+}
@@ -0,0 +1,159 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package scheduler // import "go.bonk.build/pkg/scheduler"
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"go.bonk.build/pkg/task"
+)
+
+// A scheduled task, annotated with whether it is up to date.
+type GraphNode struct {
+	Task         string   `json:"task"`
+	Name         string   `json:"name"`
+	Backend      string   `json:"backend"`
+	Inputs       []string `json:"inputs"`
+	Dependencies []string `json:"dependencies"`
+	UpToDate     bool     `json:"upToDate"`
+	// Why the task would execute. Empty if the task is up to date.
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// The tasks added to a scheduler and the edges between them, in the order they were added.
+type Graph struct {
+	Tasks []GraphNode `json:"tasks"`
+}
+
+// Returns the graph of scheduled tasks, annotated with the predictions of a dry run. Tasks
+// without a prediction are considered up to date.
+func (s *Scheduler) Graph(predictions []Prediction) *Graph {
+	reasons := make(map[string][]string, len(predictions))
+	for _, prediction := range predictions {
+		reasons[prediction.Task] = prediction.Reasons
+	}
+
+	graph := &Graph{
+		Tasks: make([]GraphNode, 0, len(s.order)),
+	}
+
+	for _, taskName := range s.order {
+		scheduled := s.tasks[taskName]
+
+		inputs := make([]string, len(scheduled.task.Inputs))
+		for idx, input := range scheduled.task.Inputs {
+			inputs[idx] = task.PortablePath(input)
+		}
+
+		graph.Tasks = append(graph.Tasks, GraphNode{
+			Task:         taskName,
+			Name:         scheduled.task.ID.Name(),
+			Backend:      scheduled.task.ID.Backend(),
+			Inputs:       inputs,
+			Dependencies: append([]string{}, scheduled.deps...),
+			UpToDate:     len(reasons[taskName]) == 0,
+			Reasons:      reasons[taskName],
+		})
+	}
+
+	return graph
+}
+
+// Writes the graph as a Graphviz digraph, with edges pointing from each task to its dependents.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintln(out, "digraph bonk {")
+	fmt.Fprintln(out, "  node [shape=box, style=filled];")
+
+	for _, node := range g.Tasks {
+		color := "palegreen"
+		if !node.UpToDate {
+			color = "lightsalmon"
+		}
+
+		fmt.Fprintf(out, "  %s [label=%s, tooltip=%s, fillcolor=%s];\n",
+			strconv.Quote(node.Task),
+			strconv.Quote(strings.Join(node.label(), "\n")),
+			strconv.Quote(strings.Join(node.Inputs, "\n")),
+			color,
+		)
+	}
+
+	for _, node := range g.Tasks {
+		for _, dep := range node.Dependencies {
+			fmt.Fprintf(out, "  %s -> %s;\n", strconv.Quote(dep), strconv.Quote(node.Task))
+		}
+	}
+
+	fmt.Fprintln(out, "}")
+
+	return out.Flush()
+}
+
+// Writes the graph as a Mermaid flowchart, with edges pointing from each task to its dependents.
+func (g *Graph) WriteMermaid(w io.Writer) error {
+	out := bufio.NewWriter(w)
+
+	// Task names contain characters Mermaid doesn't allow in ids, so number the nodes instead
+	ids := make(map[string]string, len(g.Tasks))
+
+	fmt.Fprintln(out, "flowchart LR")
+
+	for idx, node := range g.Tasks {
+		ids[node.Task] = fmt.Sprintf("task%d", idx)
+
+		class := "uptodate"
+		if !node.UpToDate {
+			class = "stale"
+		}
+
+		label := strings.ReplaceAll(strings.Join(node.label(), "<br/>"), `"`, "#quot;")
+		fmt.Fprintf(out, "  %s[\"%s\"]:::%s\n", ids[node.Task], label, class)
+	}
+
+	for _, node := range g.Tasks {
+		for _, dep := range node.Dependencies {
+			fmt.Fprintf(out, "  %s --> %s\n", ids[dep], ids[node.Task])
+		}
+	}
+
+	fmt.Fprintln(out, "  classDef uptodate fill:#98fb98")
+	fmt.Fprintln(out, "  classDef stale fill:#ffa07a")
+
+	return out.Flush()
+}
+
+// Writes the graph as indented JSON.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(g)
+	if err != nil {
+		return fmt.Errorf("failed to encode graph: %w", err)
+	}
+
+	return nil
+}
+
+// Returns the lines describing the task in rendered graphs.
+func (node *GraphNode) label() []string {
+	status := "up to date"
+	if !node.UpToDate {
+		status = "stale"
+	}
+
+	inputs := fmt.Sprintf("%d inputs", len(node.Inputs))
+	if len(node.Inputs) == 1 {
+		inputs = node.Inputs[0]
+	}
+
+	return []string{node.Name, node.Backend, inputs, status}
+}
@@ -0,0 +1,53 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package scheduler_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"cuelang.org/go/cue"
+
+	"go.bonk.build/pkg/scheduler"
+	"go.bonk.build/pkg/task"
+)
+
+func TestGraphDeduplicatesDependencies(t *testing.T) {
+	first := task.New("test:Test", "First", cue.Value{})
+	second := task.New("test:Test", "Second", cue.Value{})
+	second.Dependencies = []task.TaskId{first.ID, first.ID}
+
+	sched := scheduler.NewScheduler(nil, 1)
+
+	err := sched.AddTask(first)
+	if err != nil {
+		t.Fatalf("failed to add task: %v", err)
+	}
+
+	// Declared twice, and once more as an extra dependency
+	err = sched.AddTask(second, first.ID.String())
+	if err != nil {
+		t.Fatalf("failed to add task: %v", err)
+	}
+
+	graph := sched.Graph(nil)
+
+	want := []string{first.ID.String()}
+	if !slices.Equal(graph.Tasks[1].Dependencies, want) {
+		t.Errorf("dependencies are %v, want %v", graph.Tasks[1].Dependencies, want)
+	}
+
+	var dot strings.Builder
+
+	err = graph.WriteDOT(&dot)
+	if err != nil {
+		t.Fatalf("failed to write graph: %v", err)
+	}
+
+	edges := strings.Count(dot.String(), " -> ")
+	if edges != 1 {
+		t.Errorf("graph has %d edges, want 1:\n%s", edges, dot.String())
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package scheduler // import "go.bonk.build/pkg/scheduler"
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// The outcome of a scheduled task.
+type TaskStatus int
+
+const (
+	StatusPending TaskStatus = iota
+	StatusSucceeded
+	StatusFailed
+	StatusSkipped
+)
+
+func (status TaskStatus) String() string {
+	switch status {
+	case StatusPending:
+		return "pending"
+	case StatusSucceeded:
+		return "succeeded"
+	case StatusFailed:
+		return "failed"
+	case StatusSkipped:
+		return "skipped"
+	default:
+		return fmt.Sprintf("TaskStatus(%d)", int(status))
+	}
+}
+
+// The outcome of every task in a scheduler run.
+type Report struct {
+	Statuses map[string]TaskStatus
+	Errors   map[string]error
+}
+
+// Returns the names of the tasks which finished with the given status, sorted.
+func (r *Report) Tasks(status TaskStatus) []string {
+	names := []string{}
+	for name, taskStatus := range r.Statuses {
+		if taskStatus == status {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Returns an error describing every failed task, or nil if the build succeeded.
+func (r *Report) Err() error {
+	failed := r.Tasks(StatusFailed)
+	if len(failed) == 0 {
+		return nil
+	}
+
+	errs := make([]error, 0, len(failed)+1)
+	for _, name := range failed {
+		errs = append(errs, fmt.Errorf("task %s failed: %w", name, r.Errors[name]))
+	}
+
+	// Tasks may be skipped because a dependency failed, or because the build stopped early
+	skipped := r.Tasks(StatusSkipped)
+	switch len(skipped) {
+	case 0:
+	case 1:
+		errs = append(errs, errors.New("1 task was skipped"))
+	default:
+		errs = append(errs, fmt.Errorf("%d tasks were skipped", len(skipped)))
+	}
+
+	return errors.Join(errs...)
+}
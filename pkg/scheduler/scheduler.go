@@ -4,57 +4,273 @@
 package scheduler // import "go.bonk.build/pkg/scheduler"
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	gotaskflow "github.com/noneback/go-taskflow"
 
+	"go.bonk.build/pkg/profile"
 	"go.bonk.build/pkg/task"
 )
 
 type TaskSender interface {
-	SendTask(tsk task.Task) error
+	SendTask(ctx context.Context, tsk task.Task) error
+}
+
+// Controls how the scheduler reacts to the tasks it runs.
+type RunOptions struct {
+	// Continue executing tasks which don't depend on a failed task, like make -k.
+	KeepGoing bool
+
+	// Records how long each task waits to start once its dependencies finish, if set.
+	Profile *profile.Profile
+}
+
+type scheduledTask struct {
+	task task.Task
+	deps []string
+	// The dependencies passed to AddTask, which are added to the task once they are resolved.
+	extraDeps []string
 }
 
 type Scheduler struct {
 	backendManager TaskSender
 	executor       gotaskflow.Executor
-	tasks          map[string]*gotaskflow.Task
-	rootFlow       *gotaskflow.TaskFlow
+	tasks          map[string]*scheduledTask
+	order          []string
+
+	options  RunOptions
+	mutex    sync.Mutex
+	report   Report
+	failed   bool
+	started  time.Time
+	finished map[string]time.Time
 }
 
 func NewScheduler(backendManager TaskSender, concurrency uint) *Scheduler {
 	return &Scheduler{
 		backendManager: backendManager,
 		executor:       gotaskflow.NewExecutor(concurrency),
-		tasks:          make(map[string]*gotaskflow.Task),
-		rootFlow:       gotaskflow.NewTaskFlow("bonk"),
+		tasks:          make(map[string]*scheduledTask),
+		finished:       make(map[string]time.Time),
+		report: Report{
+			Statuses: make(map[string]TaskStatus),
+			Errors:   make(map[string]error),
+		},
 	}
 }
 
+// Adds a task to the schedule, to run after the tasks in tsk.Dependencies and deps. Tasks may
+// be added in any order, since dependencies are only resolved once the scheduler runs.
 func (s *Scheduler) AddTask(tsk task.Task, deps ...string) error {
 	taskName := tsk.ID.String()
-	newTask := s.rootFlow.NewTask(taskName, func() {
-		err := s.backendManager.SendTask(tsk)
-		if err != nil {
-			slog.Error("error executing task", "task", taskName, "error", err)
+
+	_, exists := s.tasks[taskName]
+	if exists {
+		return fmt.Errorf("task %s is already scheduled", taskName)
+	}
+
+	// Record each dependency once, however many times it was declared, so that it isn't
+	// repeated in the graph, the task's manifest, or the flow
+	seen := make(map[string]bool, len(tsk.Dependencies)+len(deps))
+	dependencies := make([]task.TaskId, 0, len(tsk.Dependencies))
+	depNames := make([]string, 0, len(tsk.Dependencies)+len(deps))
+
+	for _, dep := range tsk.Dependencies {
+		if !seen[dep.String()] {
+			seen[dep.String()] = true
+			dependencies = append(dependencies, dep)
+			depNames = append(depNames, dep.String())
 		}
-	})
+	}
+
+	extraDeps := make([]string, 0, len(deps))
 
 	for _, dep := range deps {
-		depTask, ok := s.tasks[dep]
+		if !seen[dep] {
+			seen[dep] = true
+			depNames = append(depNames, dep)
+			extraDeps = append(extraDeps, dep)
+		}
+	}
+
+	tsk.Dependencies = dependencies
+
+	s.tasks[taskName] = &scheduledTask{
+		task:      tsk,
+		deps:      depNames,
+		extraDeps: extraDeps,
+	}
+	s.order = append(s.order, taskName)
+	s.report.Statuses[taskName] = StatusPending
+
+	return nil
+}
+
+// Executes every task, skipping the dependents of any which fail.
+// Cancelling ctx skips every task which has not yet started. Nothing is executed if a
+// dependency is missing or forms a cycle.
+func (s *Scheduler) Run(ctx context.Context, options RunOptions) (*Report, error) {
+	s.options = options
+	s.started = time.Now()
+
+	ordered, err := s.resolve()
+	if err != nil {
+		return &s.report, err
+	}
+
+	rootFlow := gotaskflow.NewTaskFlow("bonk")
+	flowTasks := make(map[string]*gotaskflow.Task, len(s.tasks))
+
+	for _, taskName := range ordered {
+		scheduled := s.tasks[taskName]
+
+		flowTask := rootFlow.NewTask(taskName, func() {
+			s.runTask(ctx, taskName, scheduled)
+		})
+
+		for _, dep := range scheduled.deps {
+			flowTask.Succeed(flowTasks[dep])
+		}
+
+		flowTasks[taskName] = flowTask
+	}
+
+	s.executor.Run(rootFlow).Wait()
+
+	return &s.report, errors.Join(s.report.Err(), ctx.Err())
+}
+
+// Orders the scheduled tasks so that every task follows its dependencies. Reports the path of
+// task ids leading to the first dependency which isn't scheduled or which forms a cycle.
+func (s *Scheduler) resolve() ([]string, error) {
+	ordered := make([]string, 0, len(s.order))
+	visited := make(map[string]bool, len(s.order))
+
+	var visit func(taskName string, path []string) error
+	visit = func(taskName string, path []string) error {
+		done, seen := visited[taskName]
+		if done {
+			return nil
+		}
+
+		path = append(path, taskName)
+
+		if seen {
+			start := slices.Index(path, taskName)
+
+			return fmt.Errorf("dependency cycle detected: %s", strings.Join(path[start:], " -> "))
+		}
+
+		scheduled, ok := s.tasks[taskName]
 		if !ok {
-			return fmt.Errorf("could not find dependency task %s", dep)
+			return fmt.Errorf(
+				"could not find dependency task %s: %s",
+				taskName,
+				strings.Join(path, " -> "),
+			)
+		}
+
+		visited[taskName] = false
+
+		for _, dep := range scheduled.deps {
+			err := visit(dep, path)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, dep := range scheduled.extraDeps {
+			scheduled.task.Dependencies = append(scheduled.task.Dependencies, s.tasks[dep].task.ID)
+		}
+
+		scheduled.extraDeps = nil
+
+		visited[taskName] = true
+		ordered = append(ordered, taskName)
+
+		return nil
+	}
+
+	for _, taskName := range s.order {
+		err := visit(taskName, nil)
+		if err != nil {
+			return nil, err
 		}
+	}
 
-		newTask.Succeed(depTask)
+	return ordered, nil
+}
+
+func (s *Scheduler) runTask(ctx context.Context, taskName string, scheduled *scheduledTask) {
+	if ctx.Err() != nil || s.shouldSkip(scheduled.deps) {
+		slog.WarnContext(ctx, "skipping task", "task", taskName)
+		s.finishTask(taskName, StatusSkipped, nil)
+
+		return
 	}
 
-	s.tasks[taskName] = newTask
+	s.options.Profile.Record(taskName, profile.PhaseQueued, s.readyTime(scheduled.deps), nil)
 
-	return nil
+	err := s.backendManager.SendTask(ctx, scheduled.task)
+	if err != nil {
+		slog.ErrorContext(ctx, "error executing task", "task", taskName, "error", err)
+		s.finishTask(taskName, StatusFailed, err)
+
+		return
+	}
+
+	s.finishTask(taskName, StatusSucceeded, nil)
+}
+
+// Tasks are skipped if any dependency didn't succeed, or if any task failed and the scheduler
+// isn't configured to keep going.
+func (s *Scheduler) shouldSkip(deps []string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.failed && !s.options.KeepGoing {
+		return true
+	}
+
+	for _, dep := range deps {
+		if s.report.Statuses[dep] != StatusSucceeded {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns when the last of deps finished, or when the run started if there are none.
+func (s *Scheduler) readyTime(deps []string) time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ready := s.started
+	for _, dep := range deps {
+		if s.finished[dep].After(ready) {
+			ready = s.finished[dep]
+		}
+	}
+
+	return ready
 }
 
-func (s *Scheduler) Run() {
-	s.executor.Run(s.rootFlow).Wait()
+func (s *Scheduler) finishTask(taskName string, status TaskStatus, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.finished[taskName] = time.Now()
+	s.report.Statuses[taskName] = status
+	if err != nil {
+		s.report.Errors[taskName] = err
+		s.failed = true
+	}
 }
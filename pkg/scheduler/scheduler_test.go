@@ -0,0 +1,201 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package scheduler_test
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"cuelang.org/go/cue"
+
+	"go.bonk.build/pkg/scheduler"
+	"go.bonk.build/pkg/task"
+)
+
+// Records the tasks sent to it in the order they run.
+type recordingSender struct {
+	mutex sync.Mutex
+	sent  []task.Task
+}
+
+func (rs *recordingSender) SendTask(_ context.Context, tsk task.Task) error {
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	rs.sent = append(rs.sent, tsk)
+
+	return nil
+}
+
+// A task to schedule, by declared id, with the ids of its declared dependencies and of the
+// extra dependencies passed to AddTask.
+type testTask struct {
+	id    string
+	deps  []string
+	extra []string
+}
+
+// Returns the id of a test task, treating ids with no declaration as undeclared.
+func testTaskId(declared map[string]bool, id string) task.TaskId {
+	if !declared[id] {
+		return task.UndeclaredTaskId(id)
+	}
+
+	return task.New("test:Test", id, cue.Value{}).ID
+}
+
+func TestSchedulerRun(t *testing.T) {
+	tests := []struct {
+		name  string
+		tasks []testTask
+		err   string
+	}{
+		{
+			name: "independent",
+			tasks: []testTask{
+				{id: "A"},
+				{id: "B"},
+			},
+		},
+		{
+			name: "forward reference",
+			tasks: []testTask{
+				{id: "A", deps: []string{"B"}},
+				{id: "B"},
+			},
+		},
+		{
+			name: "diamond",
+			tasks: []testTask{
+				{id: "D", deps: []string{"B", "C"}},
+				{id: "B", deps: []string{"A"}},
+				{id: "C", deps: []string{"A"}},
+				{id: "A"},
+			},
+		},
+		{
+			name: "extra dependency",
+			tasks: []testTask{
+				{id: "B", extra: []string{"A:test:Test"}},
+				{id: "A"},
+			},
+		},
+		{
+			name: "self cycle",
+			tasks: []testTask{
+				{id: "A", deps: []string{"A"}},
+			},
+			err: "dependency cycle detected: A:test:Test -> A:test:Test",
+		},
+		{
+			name: "cycle",
+			tasks: []testTask{
+				{id: "A", deps: []string{"B"}},
+				{id: "B", deps: []string{"C"}},
+				{id: "C", deps: []string{"A"}},
+			},
+			err: "dependency cycle detected: A:test:Test -> B:test:Test -> C:test:Test -> A:test:Test",
+		},
+		{
+			name: "cycle through extra dependency",
+			tasks: []testTask{
+				{id: "A", deps: []string{"B"}},
+				{id: "B", extra: []string{"A:test:Test"}},
+			},
+			err: "dependency cycle detected: A:test:Test -> B:test:Test -> A:test:Test",
+		},
+		{
+			name: "missing dependency",
+			tasks: []testTask{
+				{id: "A", deps: []string{"B"}},
+				{id: "B", deps: []string{"Missing"}},
+			},
+			err: "could not find dependency task Missing: A:test:Test -> B:test:Test -> Missing",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			declared := make(map[string]bool, len(test.tasks))
+			for _, tt := range test.tasks {
+				declared[tt.id] = true
+			}
+
+			sender := &recordingSender{}
+			sched := scheduler.NewScheduler(sender, 4)
+
+			for _, tt := range test.tasks {
+				tsk := task.New("test:Test", tt.id, cue.Value{})
+				for _, dep := range tt.deps {
+					tsk.Dependencies = append(tsk.Dependencies, testTaskId(declared, dep))
+				}
+
+				err := sched.AddTask(tsk, tt.extra...)
+				if err != nil {
+					t.Fatalf("failed to add task %s: %v", tt.id, err)
+				}
+			}
+
+			_, err := sched.Run(t.Context(), scheduler.RunOptions{})
+
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Fatalf("got error %v, want %q", err, test.err)
+				}
+
+				if len(sender.sent) != 0 {
+					t.Errorf("ran %d tasks despite the invalid graph", len(sender.sent))
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("failed to run tasks: %v", err)
+			}
+
+			if len(sender.sent) != len(test.tasks) {
+				t.Fatalf("ran %d tasks, want %d", len(sender.sent), len(test.tasks))
+			}
+
+			ran := make([]string, len(sender.sent))
+			for idx, tsk := range sender.sent {
+				ran[idx] = tsk.ID.String()
+			}
+
+			// Every task must run after its dependencies, including the extra ones, which
+			// are passed to the task along with its declared dependencies
+			for _, tsk := range sender.sent {
+				for _, dep := range tsk.Dependencies {
+					if slices.Index(ran, dep.String()) > slices.Index(ran, tsk.ID.String()) {
+						t.Errorf("%s ran before its dependency %s: %v", tsk.ID.String(), dep.String(), ran)
+					}
+				}
+			}
+
+			for _, tt := range test.tasks {
+				idx := slices.Index(ran, tt.id+":test:Test")
+				if len(sender.sent[idx].Dependencies) != len(tt.deps)+len(tt.extra) {
+					t.Errorf("%s was passed dependencies %v", tt.id, sender.sent[idx].Dependencies)
+				}
+			}
+		})
+	}
+}
+
+func TestSchedulerRejectsDuplicateTasks(t *testing.T) {
+	sched := scheduler.NewScheduler(&recordingSender{}, 1)
+
+	err := sched.AddTask(task.New("test:Test", "A", cue.Value{}))
+	if err != nil {
+		t.Fatalf("failed to add task: %v", err)
+	}
+
+	err = sched.AddTask(task.New("test:Test", "A", cue.Value{}))
+	if err == nil {
+		t.Error("added the same task twice")
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package scheduler // import "go.bonk.build/pkg/scheduler"
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"go.bonk.build/pkg/task"
+)
+
+// Returns whether the target pattern matches the task. Patterns are globs matched against
+// either the task's declared id or its full id:backend form, and may be written with a
+// leading // like a label, such as //Test.* or //Kustomize.
+func MatchTarget(pattern string, id task.TaskId) (bool, error) {
+	pattern = strings.TrimPrefix(pattern, "//")
+
+	for _, name := range []string{id.Name(), id.String()} {
+		matched, err := path.Match(pattern, name)
+		if err != nil {
+			return false, fmt.Errorf("invalid target %s: %w", pattern, err)
+		}
+
+		if matched {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Selects the tasks matching any of the target patterns along with their transitive
+// dependencies. With reverse, every task which transitively depends on a target is selected
+// as well, so that the targets' dependents are rebuilt too. Tasks are returned in their
+// original order.
+func SelectTargets(tasks []task.Task, patterns []string, reverse bool) ([]task.Task, error) {
+	selected := make(map[string]bool, len(tasks))
+
+	for _, pattern := range patterns {
+		matchedAny := false
+
+		for _, tsk := range tasks {
+			matched, err := MatchTarget(pattern, tsk.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			if matched {
+				selected[tsk.ID.String()] = true
+				matchedAny = true
+			}
+		}
+
+		if !matchedAny {
+			return nil, fmt.Errorf("no tasks match target %s", pattern)
+		}
+	}
+
+	dependencies := make(map[string][]string, len(tasks))
+	dependents := make(map[string][]string, len(tasks))
+
+	for _, tsk := range tasks {
+		for _, dep := range tsk.Dependencies {
+			dependencies[tsk.ID.String()] = append(dependencies[tsk.ID.String()], dep.String())
+			dependents[dep.String()] = append(dependents[dep.String()], tsk.ID.String())
+		}
+	}
+
+	if reverse {
+		selectReachable(selected, dependents)
+	}
+
+	selectReachable(selected, dependencies)
+
+	result := make([]task.Task, 0, len(selected))
+
+	for _, tsk := range tasks {
+		if selected[tsk.ID.String()] {
+			result = append(result, tsk)
+		}
+	}
+
+	return result, nil
+}
+
+// Adds every task reachable from the selected tasks through edges to selected.
+func selectReachable(selected map[string]bool, edges map[string][]string) {
+	pending := slices.Collect(maps.Keys(selected))
+
+	for len(pending) > 0 {
+		taskName := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		for _, next := range edges[taskName] {
+			if !selected[next] {
+				selected[next] = true
+				pending = append(pending, next)
+			}
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package scheduler_test
+
+import (
+	"slices"
+	"testing"
+
+	"cuelang.org/go/cue"
+
+	"go.bonk.build/pkg/scheduler"
+	"go.bonk.build/pkg/task"
+)
+
+func TestMatchTarget(t *testing.T) {
+	id := task.New("test:Test", "Test", cue.Value{}).ID
+
+	tests := []struct {
+		pattern string
+		matched bool
+		err     bool
+	}{
+		{pattern: "Test", matched: true},
+		{pattern: "//Test", matched: true},
+		{pattern: "Test:test:Test", matched: true},
+		{pattern: "Te*", matched: true},
+		{pattern: "*:test:*", matched: true},
+		{pattern: "Test:other:*", matched: false},
+		{pattern: "Other", matched: false},
+		{pattern: "[", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.pattern, func(t *testing.T) {
+			matched, err := scheduler.MatchTarget(test.pattern, id)
+			if (err != nil) != test.err {
+				t.Fatalf("got error %v, want error %v", err, test.err)
+			}
+
+			if matched != test.matched {
+				t.Errorf("matched %v, want %v", matched, test.matched)
+			}
+		})
+	}
+}
+
+func TestSelectTargets(t *testing.T) {
+	ids := map[string]task.TaskId{}
+	newTask := func(id string, deps ...string) task.Task {
+		tsk := task.New("test:Test", id, cue.Value{})
+		for _, dep := range deps {
+			tsk.Dependencies = append(tsk.Dependencies, ids[dep])
+		}
+
+		ids[id] = tsk.ID
+
+		return tsk
+	}
+
+	// C depends on B, which depends on both A and X. D stands alone.
+	tasks := []task.Task{
+		newTask("A"),
+		newTask("X"),
+		newTask("B", "A", "X"),
+		newTask("C", "B"),
+		newTask("D"),
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		reverse  bool
+		selected []string
+		err      bool
+	}{
+		{
+			name:     "leaf",
+			patterns: []string{"A"},
+			selected: []string{"A"},
+		},
+		{
+			name:     "dependencies",
+			patterns: []string{"C"},
+			selected: []string{"A", "X", "B", "C"},
+		},
+		{
+			name:     "several targets",
+			patterns: []string{"B", "D"},
+			selected: []string{"A", "X", "B", "D"},
+		},
+		{
+			name:     "glob",
+			patterns: []string{"*"},
+			selected: []string{"A", "X", "B", "C", "D"},
+		},
+		{
+			name:     "reverse dependencies",
+			patterns: []string{"A"},
+			reverse:  true,
+			selected: []string{"A", "X", "B", "C"},
+		},
+		{
+			name:     "reverse dependencies of a root",
+			patterns: []string{"C"},
+			reverse:  true,
+			selected: []string{"A", "X", "B", "C"},
+		},
+		{
+			name:     "unmatched target",
+			patterns: []string{"A", "Missing"},
+			err:      true,
+		},
+		{
+			name:     "invalid target",
+			patterns: []string{"["},
+			err:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selected, err := scheduler.SelectTargets(tasks, test.patterns, test.reverse)
+			if (err != nil) != test.err {
+				t.Fatalf("got error %v, want error %v", err, test.err)
+			}
+
+			names := []string{}
+			for _, tsk := range selected {
+				names = append(names, tsk.ID.Name())
+			}
+
+			if !test.err && !slices.Equal(names, test.selected) {
+				t.Errorf("selected %v, want %v", names, test.selected)
+			}
+		})
+	}
+}
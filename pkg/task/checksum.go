@@ -0,0 +1,193 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package task // import "go.bonk.build/pkg/task"
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// The version of the scheme used by GenerateChecksum. Bump it whenever the digested fields
+// change, so that checksums saved by older versions of bonk are never mistaken for current ones.
+const ChecksumVersion = 5
+
+// Writes named fields into a hash, length-prefixing each name and value so that no two
+// sequences of fields produce the same stream of bytes.
+type digestWriter struct {
+	hasher hash.Hash
+}
+
+func newDigestWriter() *digestWriter {
+	return &digestWriter{
+		hasher: sha256.New(),
+	}
+}
+
+func (dw *digestWriter) field(name string, value []byte) {
+	var length [8]byte
+
+	binary.BigEndian.PutUint64(length[:], uint64(len(name)))
+	dw.hasher.Write(length[:])
+	dw.hasher.Write([]byte(name))
+
+	binary.BigEndian.PutUint64(length[:], uint64(len(value)))
+	dw.hasher.Write(length[:])
+	dw.hasher.Write(value)
+}
+
+func (dw *digestWriter) sum() []byte {
+	return dw.hasher.Sum(nil)
+}
+
+// Returns the task's parameters as compact JSON with object keys sorted, so that the encoding
+// doesn't depend on the order fields were declared or unified in.
+func (t *Task) CanonicalParams() ([]byte, error) {
+	paramsJSON, err := t.Params.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal task params: %w", err)
+	}
+
+	// Decode numbers as json.Number so that they are re-encoded exactly as written
+	decoder := json.NewDecoder(bytes.NewReader(paramsJSON))
+	decoder.UseNumber()
+
+	var params any
+
+	err = decoder.Decode(&params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode task params: %w", err)
+	}
+
+	// encoding/json sorts map keys
+	canonical, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode task params: %w", err)
+	}
+
+	return canonical, nil
+}
+
+// Computes the current version of the task's checksum from its manifest.
+func (t *Task) digest() ([]byte, error) {
+	manifest, err := t.Manifest()
+	if err != nil {
+		return nil, err
+	}
+
+	return manifest.digest(), nil
+}
+
+// Hashes the contents of each input file, in parallel when the task has a FileHashes to bound
+// the number of files read at once.
+func (t *Task) hashInputs() ([][]byte, error) {
+	hashes := make([][]byte, len(t.Inputs))
+
+	var group errgroup.Group
+
+	group.SetLimit(t.Hashes.concurrency())
+
+	for idx, file := range t.Inputs {
+		group.Go(func() error {
+			hash, err := t.Hashes.Hash(file)
+			if err != nil {
+				return fmt.Errorf("failed to hash input file %s: %w", file, err)
+			}
+
+			hashes[idx] = hash
+
+			return nil
+		})
+	}
+
+	err := group.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	return hashes, nil
+}
+
+// Hashes the result of each dependency, as it is passed to the task's backend. Dependencies
+// which haven't been built yet have an empty hash.
+func (t *Task) hashDependencies() ([]ManifestDependency, error) {
+	dependencies := make([]ManifestDependency, len(t.Dependencies))
+
+	for idx, dep := range t.Dependencies {
+		dependencies[idx].Task = dep.String()
+
+		result, err := dep.LoadResult()
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to load result of dependency %s: %w", dep.String(), err)
+		}
+
+		// Re-encode the result so that the hash doesn't depend on how the file was written
+		resultJSON, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode result of dependency %s: %w", dep.String(), err)
+		}
+
+		hash := sha256.Sum256(resultJSON)
+		dependencies[idx].Result = hash[:]
+	}
+
+	return dependencies, nil
+}
+
+// Computes the checksum used before checksums were versioned, so that existing checksum files
+// can be migrated without rebuilding.
+func (t *Task) legacyDigest() ([]byte, error) {
+	hasher := sha256.New()
+
+	hasher.Write([]byte(t.ID.backend))
+
+	for _, file := range t.Inputs {
+		fileBytes, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash input file %s: %w", file, err)
+		}
+
+		hasher.Write(fileBytes)
+	}
+
+	paramsJSON, err := t.Params.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal task params: %w", err)
+	}
+
+	hasher.Write(paramsJSON)
+
+	return hasher.Sum(nil), nil
+}
+
+// Returns file relative to the working directory where possible, so that checksums don't
+// depend on where the project is checked out.
+func PortablePath(file string) string {
+	if !filepath.IsAbs(file) {
+		return filepath.ToSlash(file)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+
+	relPath, err := filepath.Rel(cwd, file)
+	if err != nil || !filepath.IsLocal(relPath) {
+		return filepath.ToSlash(file)
+	}
+
+	return filepath.ToSlash(relPath)
+}
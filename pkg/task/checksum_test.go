@@ -0,0 +1,224 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package task_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+
+	"go.bonk.build/pkg/task"
+)
+
+func writeFile(t *testing.T, file, contents string) {
+	t.Helper()
+
+	err := os.WriteFile(file, []byte(contents), 0o600)
+	if err != nil {
+		t.Fatalf("failed to write %s: %v", file, err)
+	}
+}
+
+func checksum(t *testing.T, tsk task.Task) []byte {
+	t.Helper()
+
+	digest, err := tsk.GenerateChecksum()
+	if err != nil {
+		t.Fatalf("failed to checksum task: %v", err)
+	}
+
+	return digest
+}
+
+func TestCanonicalParams(t *testing.T) {
+	cuectx := cuecontext.New()
+
+	tests := []struct {
+		name   string
+		params string
+		want   string
+	}{
+		{
+			name:   "sorted keys",
+			params: `{b: 2, a: 1}`,
+			want:   `{"a":1,"b":2}`,
+		},
+		{
+			name:   "nested keys",
+			params: `{outer: {z: true, y: [3, 2, 1]}}`,
+			want:   `{"outer":{"y":[3,2,1],"z":true}}`,
+		},
+		{
+			name:   "unified fields",
+			params: `{a: _} & {b: "x"} & {a: "y"}`,
+			want:   `{"a":"y","b":"x"}`,
+		},
+		{
+			name:   "large numbers",
+			params: `{n: 12345678901234567890}`,
+			want:   `{"n":12345678901234567890}`,
+		},
+		{
+			name:   "empty",
+			params: `{}`,
+			want:   `{}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tsk := task.New("test:Test", "Test", cuectx.CompileString(test.params))
+
+			params, err := tsk.CanonicalParams()
+			if err != nil {
+				t.Fatalf("failed to encode params: %v", err)
+			}
+
+			if string(params) != test.want {
+				t.Errorf("encoded params as %s, want %s", params, test.want)
+			}
+		})
+	}
+}
+
+func TestChecksumChanges(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	cuectx := cuecontext.New()
+
+	writeFile(t, "a.txt", "a")
+	writeFile(t, "b.txt", "b")
+	writeFile(t, "copy.txt", "a")
+
+	base := func() task.Task {
+		return task.New(
+			"test:Test",
+			"Test",
+			cuectx.CompileString(`{a: 1, b: {c: "x"}}`),
+			"a.txt",
+			"b.txt",
+		)
+	}
+
+	baseline := checksum(t, base())
+
+	tests := []struct {
+		name    string
+		modify  func(tsk *task.Task)
+		changed bool
+	}{
+		{
+			name:    "unchanged",
+			modify:  func(*task.Task) {},
+			changed: false,
+		},
+		{
+			name: "reordered params",
+			modify: func(tsk *task.Task) {
+				tsk.Params = cuectx.CompileString(`{b: {c: "x"}, a: 1}`)
+			},
+			changed: false,
+		},
+		{
+			name: "changed param",
+			modify: func(tsk *task.Task) {
+				tsk.Params = cuectx.CompileString(`{a: 2, b: {c: "x"}}`)
+			},
+			changed: true,
+		},
+		{
+			name: "changed nested param",
+			modify: func(tsk *task.Task) {
+				tsk.Params = cuectx.CompileString(`{a: 1, b: {c: "y"}}`)
+			},
+			changed: true,
+		},
+		{
+			name: "backend version",
+			modify: func(tsk *task.Task) {
+				tsk.BackendVersion = "v1.0.0"
+			},
+			changed: true,
+		},
+		{
+			name: "reordered inputs",
+			modify: func(tsk *task.Task) {
+				tsk.Inputs = []string{"b.txt", "a.txt"}
+			},
+			changed: true,
+		},
+		{
+			name: "renamed input with the same contents",
+			modify: func(tsk *task.Task) {
+				tsk.Inputs = []string{"copy.txt", "b.txt"}
+			},
+			changed: true,
+		},
+		{
+			name: "removed input",
+			modify: func(tsk *task.Task) {
+				tsk.Inputs = []string{"a.txt"}
+			},
+			changed: true,
+		},
+		{
+			name: "added dependency",
+			modify: func(tsk *task.Task) {
+				tsk.Dependencies = []task.TaskId{task.UndeclaredTaskId("Other")}
+			},
+			changed: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tsk := base()
+			test.modify(&tsk)
+
+			changed := !bytes.Equal(checksum(t, tsk), baseline)
+			if changed != test.changed {
+				t.Errorf("checksum changed: %v, want %v", changed, test.changed)
+			}
+		})
+	}
+}
+
+func TestChecksumChangesWithInputContents(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	params := cuecontext.New().CompileString(`{}`)
+
+	writeFile(t, "input.txt", "before")
+	before := checksum(t, task.New("test:Test", "Test", params, "input.txt"))
+
+	writeFile(t, "input.txt", "after")
+	after := checksum(t, task.New("test:Test", "Test", params, "input.txt"))
+
+	if bytes.Equal(before, after) {
+		t.Error("checksum didn't change with the contents of an input")
+	}
+}
+
+func TestChecksumIsPortable(t *testing.T) {
+	params := cuecontext.New().CompileString(`{message: "hello"}`)
+	checksums := [][]byte{}
+
+	// The same project checked out in two directories, with inputs given by absolute path
+	for range 2 {
+		dir := t.TempDir()
+		t.Chdir(dir)
+
+		writeFile(t, "input.txt", "input")
+
+		tsk := task.New("test:Test", "Test", params, filepath.Join(dir, "input.txt"))
+		checksums = append(checksums, checksum(t, tsk))
+	}
+
+	if !bytes.Equal(checksums[0], checksums[1]) {
+		t.Error("checksum depends on the directory the project is checked out in")
+	}
+}
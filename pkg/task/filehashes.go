@@ -0,0 +1,160 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package task // import "go.bonk.build/pkg/task"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Files modified this recently may still be changing within the resolution of their
+// modification time, so their hashes aren't remembered.
+const racyWindow = 2 * time.Second
+
+// Returns the file in which input file hashes are persisted between builds.
+func GetFileHashesFile() string {
+	return path.Join(".bonk", ".hashes")
+}
+
+type fileHashEntry struct {
+	Size  int64  `json:"size"`
+	MTime int64  `json:"mtime"`
+	Inode uint64 `json:"inode"`
+	Hash  []byte `json:"hash"`
+}
+
+// A persistent cache of file content hashes, keyed by path and invalidated whenever a file's
+// size, modification time, or inode changes, so that unchanged inputs aren't re-read on every
+// build. Safe for concurrent use.
+type FileHashes struct {
+	file      string
+	mutex     sync.Mutex
+	entries   map[string]fileHashEntry
+	used      map[string]bool
+	semaphore chan struct{}
+}
+
+// Loads the hashes saved in file, hashing no more than concurrency files at once.
+// A missing or unreadable file starts an empty cache.
+func LoadFileHashes(file string, concurrency uint) *FileHashes {
+	fh := &FileHashes{
+		file:      file,
+		entries:   make(map[string]fileHashEntry),
+		used:      make(map[string]bool),
+		semaphore: make(chan struct{}, max(concurrency, 1)),
+	}
+
+	hashesBytes, err := os.ReadFile(file)
+	if err == nil {
+		err = json.Unmarshal(hashesBytes, &fh.entries)
+		if err != nil {
+			fh.entries = make(map[string]fileHashEntry)
+		}
+	}
+
+	return fh
+}
+
+// Returns the number of files which may be hashed at once, which is one for a nil FileHashes.
+func (fh *FileHashes) concurrency() int {
+	if fh == nil {
+		return 1
+	}
+
+	return cap(fh.semaphore)
+}
+
+// Returns the sha256 hash of the file's contents, re-reading it only if it has changed since it
+// was last hashed. A nil FileHashes always reads the file.
+func (fh *FileHashes) Hash(file string) ([]byte, error) {
+	if fh == nil {
+		return hashFile(file)
+	}
+
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", file, err)
+	}
+
+	info, err := os.Stat(absFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat input: %w", err)
+	}
+
+	stat := fileHashEntry{
+		Size:  info.Size(),
+		MTime: info.ModTime().UnixNano(),
+		Inode: inode(info),
+	}
+
+	fh.mutex.Lock()
+	entry, ok := fh.entries[absFile]
+	fh.used[absFile] = true
+	fh.mutex.Unlock()
+
+	if ok && entry.Size == stat.Size && entry.MTime == stat.MTime && entry.Inode == stat.Inode {
+		return entry.Hash, nil
+	}
+
+	fh.semaphore <- struct{}{}
+	hash, err := hashFile(absFile)
+	<-fh.semaphore
+
+	if err != nil {
+		return nil, err
+	}
+
+	stat.Hash = hash
+
+	fh.mutex.Lock()
+	if time.Since(info.ModTime()) > racyWindow {
+		fh.entries[absFile] = stat
+	} else {
+		delete(fh.entries, absFile)
+	}
+	fh.mutex.Unlock()
+
+	return hash, nil
+}
+
+// Writes the cache back to its file, forgetting files which no longer exist.
+func (fh *FileHashes) Save() error {
+	fh.mutex.Lock()
+	defer fh.mutex.Unlock()
+
+	for file := range fh.entries {
+		if fh.used[file] {
+			continue
+		}
+
+		_, err := os.Stat(file)
+		if errors.Is(err, fs.ErrNotExist) {
+			delete(fh.entries, file)
+		}
+	}
+
+	hashesBytes, err := json.Marshal(fh.entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode file hashes: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(fh.file), 0o750)
+	if err != nil {
+		return fmt.Errorf("failed to create file hashes directory: %w", err)
+	}
+
+	err = os.WriteFile(fh.file, hashesBytes, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write file hashes: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+//go:build !unix
+
+package task // import "go.bonk.build/pkg/task"
+
+import "io/fs"
+
+// Inodes aren't available, so rely on size and modification time alone.
+func inode(fs.FileInfo) uint64 {
+	return 0
+}
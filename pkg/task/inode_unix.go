@@ -0,0 +1,20 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+//go:build unix
+
+package task // import "go.bonk.build/pkg/task"
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+func inode(info fs.FileInfo) uint64 {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0
+	}
+
+	return uint64(stat.Ino)
+}
@@ -0,0 +1,348 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package task // import "go.bonk.build/pkg/task"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+)
+
+// The individual components hashed into a task's checksum, saved alongside it so that the
+// reason a task is rebuilt can be explained.
+type Manifest struct {
+	Version        int             `json:"version"`
+	Backend        string          `json:"backend"`
+	BackendVersion string          `json:"backendVersion"`
+	Inputs         []ManifestInput `json:"inputs"`
+	Params         json.RawMessage `json:"params"`
+	// The results of the task's dependencies, which are passed to its backend.
+	Dependencies []ManifestDependency `json:"dependencies"`
+}
+
+// An input file, by path relative to the project directory where possible.
+type ManifestInput struct {
+	Path string `json:"path"`
+	Hash []byte `json:"hash"`
+}
+
+// A dependency of the task, by task id, along with the hash of its result. The hash is empty if
+// the dependency hasn't been built.
+type ManifestDependency struct {
+	Task   string `json:"task"`
+	Result []byte `json:"result"`
+}
+
+// Hashes the manifest's components, covering the checksum version, the backend and its
+// version, the path and content hash of each input, the canonical parameters, and the result
+// of each dependency.
+func (m *Manifest) digest() []byte {
+	dw := newDigestWriter()
+
+	dw.field("version", []byte(strconv.Itoa(m.Version)))
+	dw.field("backend", []byte(m.Backend))
+	dw.field("backend.version", []byte(m.BackendVersion))
+
+	for _, input := range m.Inputs {
+		dw.field("input.path", []byte(input.Path))
+		dw.field("input.hash", input.Hash)
+	}
+
+	dw.field("params", m.Params)
+
+	for _, dep := range m.Dependencies {
+		dw.field("dependency.task", []byte(dep.Task))
+		dw.field("dependency.result", dep.Result)
+	}
+
+	return dw.sum()
+}
+
+// Describes each difference between a previous manifest and m.
+func (m *Manifest) Diff(previous *Manifest) []string {
+	changes := []string{}
+
+	if previous.Version != m.Version {
+		changes = append(changes, fmt.Sprintf(
+			"checksum scheme changed from version %d to %d", previous.Version, m.Version,
+		))
+	}
+
+	if previous.Backend != m.Backend {
+		changes = append(changes, fmt.Sprintf(
+			"backend changed from %s to %s", previous.Backend, m.Backend,
+		))
+	}
+
+	if previous.BackendVersion != m.BackendVersion {
+		changes = append(changes, fmt.Sprintf(
+			"backend version changed from %q to %q", previous.BackendVersion, m.BackendVersion,
+		))
+	}
+
+	changes = append(changes, diffInputs(previous.Inputs, m.Inputs)...)
+
+	if !bytes.Equal(previous.Params, m.Params) {
+		var previousParams, currentParams any
+
+		_ = json.Unmarshal(previous.Params, &previousParams)
+		_ = json.Unmarshal(m.Params, &currentParams)
+
+		changes = append(changes, diffValues("params", previousParams, currentParams)...)
+	}
+
+	changes = append(changes, diffDependencies(previous.Dependencies, m.Dependencies)...)
+
+	return changes
+}
+
+func diffDependencies(previous, current []ManifestDependency) []string {
+	changes := []string{}
+
+	previousResults := make(map[string][]byte, len(previous))
+	for _, dep := range previous {
+		previousResults[dep.Task] = dep.Result
+	}
+
+	currentTasks := make([]string, 0, len(current))
+
+	for _, dep := range current {
+		currentTasks = append(currentTasks, dep.Task)
+
+		result, ok := previousResults[dep.Task]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("dependency %s was added", dep.Task))
+		case !bytes.Equal(result, dep.Result):
+			changes = append(changes, fmt.Sprintf("result of dependency %s changed", dep.Task))
+		}
+	}
+
+	for _, dep := range previous {
+		if !slices.Contains(currentTasks, dep.Task) {
+			changes = append(changes, fmt.Sprintf("dependency %s was removed", dep.Task))
+		}
+	}
+
+	return changes
+}
+
+func diffInputs(previous, current []ManifestInput) []string {
+	changes := []string{}
+
+	previousHashes := make(map[string][]byte, len(previous))
+	for _, input := range previous {
+		previousHashes[input.Path] = input.Hash
+	}
+
+	currentPaths := make([]string, 0, len(current))
+
+	for _, input := range current {
+		currentPaths = append(currentPaths, input.Path)
+
+		hash, ok := previousHashes[input.Path]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("input %s was added", input.Path))
+		case !bytes.Equal(hash, input.Hash):
+			changes = append(changes, fmt.Sprintf("input %s changed", input.Path))
+		}
+	}
+
+	for _, input := range previous {
+		if !slices.Contains(currentPaths, input.Path) {
+			changes = append(changes, fmt.Sprintf("input %s was removed", input.Path))
+		}
+	}
+
+	// Inputs are hashed in order, so reordering them also changes the checksum
+	if len(changes) == 0 && len(previous) == len(current) {
+		for idx := range current {
+			if previous[idx].Path != current[idx].Path {
+				changes = append(changes, "inputs were reordered")
+
+				break
+			}
+		}
+	}
+
+	return changes
+}
+
+// Describes the differences between two decoded JSON values, naming each changed field by its
+// path from name.
+func diffValues(name string, previous, current any) []string {
+	previousMap, previousIsMap := previous.(map[string]any)
+	currentMap, currentIsMap := current.(map[string]any)
+
+	if !previousIsMap || !currentIsMap {
+		previousJSON, _ := json.Marshal(previous)
+		currentJSON, _ := json.Marshal(current)
+
+		if bytes.Equal(previousJSON, currentJSON) {
+			return nil
+		}
+
+		return []string{fmt.Sprintf("%s changed from %s to %s", name, previousJSON, currentJSON)}
+	}
+
+	changes := []string{}
+
+	keys := make([]string, 0, len(previousMap)+len(currentMap))
+	for key := range previousMap {
+		keys = append(keys, key)
+	}
+
+	for key := range currentMap {
+		_, shared := previousMap[key]
+		if !shared {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		field := name + "." + key
+		previousValue, hadPrevious := previousMap[key]
+		currentValue, hasCurrent := currentMap[key]
+
+		switch {
+		case !hadPrevious:
+			changes = append(changes, field+" was added")
+		case !hasCurrent:
+			changes = append(changes, field+" was removed")
+		default:
+			changes = append(changes, diffValues(field, previousValue, currentValue)...)
+		}
+	}
+
+	return changes
+}
+
+// Collects the components of the task's checksum.
+func (t *Task) Manifest() (*Manifest, error) {
+	if t.manifest != nil {
+		return t.manifest, nil
+	}
+
+	hashes, err := t.hashInputs()
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := t.CanonicalParams()
+	if err != nil {
+		return nil, err
+	}
+
+	dependencies, err := t.hashDependencies()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{
+		Version:        ChecksumVersion,
+		Backend:        t.ID.backend,
+		BackendVersion: t.BackendVersion,
+		Inputs:         make([]ManifestInput, len(t.Inputs)),
+		Params:         params,
+		Dependencies:   dependencies,
+	}
+
+	for idx, file := range t.Inputs {
+		manifest.Inputs[idx] = ManifestInput{
+			Path: PortablePath(file),
+			Hash: hashes[idx],
+		}
+	}
+
+	t.manifest = manifest
+
+	return manifest, nil
+}
+
+func (id *TaskId) GetManifestFile() string {
+	return path.Join(id.GetOutputDirectory(), ".manifest")
+}
+
+// Loads the manifest saved by the last successful execution of the task.
+func (id *TaskId) LoadManifest() (*Manifest, error) {
+	manifestBytes, err := os.ReadFile(id.GetManifestFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest file: %w", err)
+	}
+
+	manifest := &Manifest{}
+
+	err = json.Unmarshal(manifestBytes, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode manifest file: %w", err)
+	}
+
+	return manifest, nil
+}
+
+func (t *Task) saveManifest() error {
+	manifest, err := t.Manifest()
+	if err != nil {
+		return err
+	}
+
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	err = os.WriteFile(t.ID.GetManifestFile(), manifestBytes, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write manifest file: %w", err)
+	}
+
+	return nil
+}
+
+// Describes why the task isn't up to date, by comparing the manifest saved by its last
+// successful execution against its current one. Returns nothing if the task is up to date.
+func (t *Task) Explain() []string {
+	previous, err := t.ID.LoadManifest()
+	if errors.Is(err, fs.ErrNotExist) {
+		version, checksum, checksumErr := t.ID.LoadChecksum()
+		if checksumErr != nil {
+			return []string{"task has not been built"}
+		}
+
+		// Checksums from before versioning are migrated rather than rebuilt when they match.
+		// They were saved without output hashes, so their outputs can't be verified.
+		if version == 1 {
+			legacyChecksum, _ := t.legacyDigest()
+			if bytes.Equal(checksum, legacyChecksum) {
+				return nil
+			}
+		}
+
+		return []string{"task was built before manifests were recorded"}
+	} else if err != nil {
+		return []string{err.Error()}
+	}
+
+	current, err := t.Manifest()
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	changes := current.Diff(previous)
+
+	if len(changes) == 0 && !t.CheckOutputs() {
+		changes = append(changes, "outputs were modified or removed")
+	}
+
+	return changes
+}
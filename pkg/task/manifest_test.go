@@ -0,0 +1,131 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package task_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"go.bonk.build/pkg/task"
+)
+
+func TestManifestDiff(t *testing.T) {
+	base := func() *task.Manifest {
+		return &task.Manifest{
+			Version:        task.ChecksumVersion,
+			Backend:        "test:Test",
+			BackendVersion: "v1.0.0",
+			Inputs: []task.ManifestInput{
+				{Path: "a.txt", Hash: []byte{1}},
+				{Path: "b.txt", Hash: []byte{2}},
+			},
+			Params: json.RawMessage(`{"a":1,"b":{"c":"x"}}`),
+			Dependencies: []task.ManifestDependency{
+				{Task: "Dep:test:Test", Result: []byte{3}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(m *task.Manifest)
+		changes []string
+	}{
+		{
+			name:    "unchanged",
+			modify:  func(*task.Manifest) {},
+			changes: []string{},
+		},
+		{
+			name: "version",
+			modify: func(m *task.Manifest) {
+				m.Version = 1
+			},
+			changes: []string{"checksum scheme changed from version 1 to 5"},
+		},
+		{
+			name: "backend",
+			modify: func(m *task.Manifest) {
+				m.Backend = "other:Other"
+			},
+			changes: []string{"backend changed from other:Other to test:Test"},
+		},
+		{
+			name: "backend version",
+			modify: func(m *task.Manifest) {
+				m.BackendVersion = "v0.9.0"
+			},
+			changes: []string{`backend version changed from "v0.9.0" to "v1.0.0"`},
+		},
+		{
+			name: "changed input",
+			modify: func(m *task.Manifest) {
+				m.Inputs[0].Hash = []byte{9}
+			},
+			changes: []string{"input a.txt changed"},
+		},
+		{
+			name: "added and removed inputs",
+			modify: func(m *task.Manifest) {
+				m.Inputs[1].Path = "old.txt"
+			},
+			changes: []string{"input b.txt was added", "input old.txt was removed"},
+		},
+		{
+			name: "reordered inputs",
+			modify: func(m *task.Manifest) {
+				m.Inputs[0], m.Inputs[1] = m.Inputs[1], m.Inputs[0]
+			},
+			changes: []string{"inputs were reordered"},
+		},
+		{
+			name: "changed params",
+			modify: func(m *task.Manifest) {
+				m.Params = json.RawMessage(`{"a":2,"b":{"c":"y"},"d":true}`)
+			},
+			changes: []string{
+				"params.a changed from 2 to 1",
+				`params.b.c changed from "y" to "x"`,
+				"params.d was removed",
+			},
+		},
+		{
+			name: "added param",
+			modify: func(m *task.Manifest) {
+				m.Params = json.RawMessage(`{"a":1}`)
+			},
+			changes: []string{"params.b was added"},
+		},
+		{
+			name: "changed dependency result",
+			modify: func(m *task.Manifest) {
+				m.Dependencies[0].Result = nil
+			},
+			changes: []string{"result of dependency Dep:test:Test changed"},
+		},
+		{
+			name: "added and removed dependencies",
+			modify: func(m *task.Manifest) {
+				m.Dependencies[0].Task = "Old:test:Test"
+			},
+			changes: []string{
+				"dependency Dep:test:Test was added",
+				"dependency Old:test:Test was removed",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			previous := base()
+			test.modify(previous)
+
+			changes := base().Diff(previous)
+			if !slices.Equal(changes, test.changes) {
+				t.Errorf("got changes %q, want %q", changes, test.changes)
+			}
+		})
+	}
+}
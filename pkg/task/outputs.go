@@ -0,0 +1,138 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package task // import "go.bonk.build/pkg/task"
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// Returns whether a path relative to a task's output directory holds bonk's own metadata,
+// rather than something produced by the backend.
+func IsMetadata(relPath string) bool {
+	return !strings.ContainsRune(relPath, filepath.Separator) && strings.HasPrefix(relPath, ".")
+}
+
+func (id *TaskId) GetOutputsFile() string {
+	return path.Join(id.GetOutputDirectory(), ".outputs")
+}
+
+// Loads the output file hashes recorded by the last successful execution of the task.
+func (id *TaskId) LoadOutputHashes() (map[string]string, error) {
+	hashesBytes, err := os.ReadFile(id.GetOutputsFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outputs file: %w", err)
+	}
+
+	hashes := make(map[string]string)
+
+	err = json.Unmarshal(hashesBytes, &hashes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode outputs file: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// Hashes every file in the task's output directory, keyed by path relative to it.
+func (t *Task) HashOutputs() (map[string]string, error) {
+	outDir := t.GetOutputDirectory()
+	hashes := make(map[string]string)
+
+	err := filepath.WalkDir(outDir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(outDir, file)
+		if err != nil {
+			return fmt.Errorf("failed to relativize output %s: %w", file, err)
+		}
+
+		if entry.IsDir() {
+			if relPath != "." && IsMetadata(relPath) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if IsMetadata(relPath) {
+			return nil
+		}
+
+		hash, err := hashFile(file)
+		if err != nil {
+			return err
+		}
+
+		hashes[filepath.ToSlash(relPath)] = base64.StdEncoding.EncodeToString(hash)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash outputs: %w", err)
+	}
+
+	return hashes, nil
+}
+
+func (t *Task) SaveOutputHashes() error {
+	hashes, err := t.HashOutputs()
+	if err != nil {
+		return err
+	}
+
+	hashesBytes, err := json.Marshal(hashes)
+	if err != nil {
+		return fmt.Errorf("failed to encode output hashes: %w", err)
+	}
+
+	err = os.WriteFile(t.ID.GetOutputsFile(), hashesBytes, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write outputs file: %w", err)
+	}
+
+	return nil
+}
+
+// Returns whether the task's outputs are unchanged since they were last recorded.
+func (t *Task) CheckOutputs() bool {
+	savedHashes, _ := t.ID.LoadOutputHashes()
+	if savedHashes != nil {
+		newHashes, _ := t.HashOutputs()
+
+		if reflect.DeepEqual(savedHashes, newHashes) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hashFile(file string) ([]byte, error) {
+	reader, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", file, err)
+	}
+	defer reader.Close()
+
+	hasher := sha256.New()
+
+	_, err = io.Copy(hasher, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash %s: %w", file, err)
+	}
+
+	return hasher.Sum(nil), nil
+}
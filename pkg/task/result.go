@@ -0,0 +1,65 @@
+// Copyright © 2025 Colden Cullen
+// SPDX-License-Identifier: MIT
+
+package task // import "go.bonk.build/pkg/task"
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+)
+
+// A message reported by a backend while executing a task.
+type Diagnostic struct {
+	Severity slog.Level `json:"severity"`
+	Message  string     `json:"message"`
+}
+
+// The outcome of a task, as reported by its backend.
+type Result struct {
+	// The files produced by the task, relative to its output directory.
+	Outputs     []string          `json:"outputs,omitempty"`
+	Diagnostics []Diagnostic      `json:"diagnostics,omitempty"`
+	Values      map[string]string `json:"values,omitempty"`
+}
+
+func (id *TaskId) GetResultFile() string {
+	return path.Join(id.GetOutputDirectory(), ".result")
+}
+
+// Loads the result recorded by the last successful execution of the task.
+func (id *TaskId) LoadResult() (*Result, error) {
+	resultBytes, err := os.ReadFile(id.GetResultFile())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read result file: %w", err)
+	}
+
+	result := &Result{}
+
+	err = json.Unmarshal(resultBytes, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode result file: %w", err)
+	}
+
+	return result, nil
+}
+
+func (t *Task) SaveResult(result *Result) error {
+	if result == nil {
+		result = &Result{}
+	}
+
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+
+	err = os.WriteFile(t.ID.GetResultFile(), resultBytes, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to write result file: %w", err)
+	}
+
+	return nil
+}
@@ -4,12 +4,14 @@
 package task // import "go.bonk.build/pkg/task"
 
 import (
-	"crypto/sha256"
+	"bytes"
 	"encoding/base64"
 	"fmt"
+	"log/slog"
 	"os"
 	"path"
-	"reflect"
+	"strconv"
+	"strings"
 
 	"cuelang.org/go/cue"
 )
@@ -19,7 +21,26 @@ type TaskId struct {
 	backend string
 }
 
+// Returns the id the task was declared with.
+func (id *TaskId) Name() string {
+	return id.id
+}
+
+// Returns the backend which executes the task.
+func (id *TaskId) Backend() string {
+	return id.backend
+}
+
+// Returns the id of a task which is referred to but wasn't declared, so has no backend.
+func UndeclaredTaskId(id string) TaskId {
+	return TaskId{id: id}
+}
+
 func (id *TaskId) String() string {
+	if id.backend == "" {
+		return id.id
+	}
+
 	return fmt.Sprintf("%s:%s", id.id, id.backend)
 }
 
@@ -31,18 +52,33 @@ func (id *TaskId) GetChecksumFile() string {
 	return path.Join(id.GetOutputDirectory(), ".checksum")
 }
 
-func (id *TaskId) LoadChecksum() ([]byte, error) {
-	checksumString, err := os.ReadFile(id.GetChecksumFile())
+// Loads the saved checksum along with the version of the scheme which produced it. Checksums
+// saved before versioning was introduced are reported as version 1.
+func (id *TaskId) LoadChecksum() (int, []byte, error) {
+	checksumBytes, err := os.ReadFile(id.GetChecksumFile())
 	if err != nil {
-		return nil, fmt.Errorf("failed to read checksum file: %w", err)
+		return 0, nil, fmt.Errorf("failed to read checksum file: %w", err)
 	}
 
-	checksum, err := base64.StdEncoding.DecodeString(string(checksumString))
+	version := 1
+	checksumString := string(checksumBytes)
+
+	versionString, encoded, ok := strings.Cut(checksumString, ":")
+	if ok {
+		version, err = strconv.Atoi(versionString)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to parse checksum version: %w", err)
+		}
+
+		checksumString = encoded
+	}
+
+	checksum, err := base64.StdEncoding.DecodeString(checksumString)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode checksum base64: %w", err)
+		return 0, nil, fmt.Errorf("failed to decode checksum base64: %w", err)
 	}
 
-	return checksum, nil
+	return version, checksum, nil
 }
 
 type Task struct {
@@ -51,7 +87,17 @@ type Task struct {
 	Inputs []string
 	Params cue.Value
 
+	// Identifies the implementation of the task's backend, so that upgrading it rebuilds the task.
+	BackendVersion string
+
+	// Remembers input file hashes between builds. When nil, inputs are always re-read.
+	Hashes *FileHashes
+
+	// The tasks which must complete before this one, whose results are made available to it.
+	Dependencies []TaskId
+
 	checksum []byte
+	manifest *Manifest
 }
 
 func New(backend, id string, params cue.Value, inputs ...string) Task {
@@ -79,42 +125,39 @@ func (t *Task) GenerateChecksum() ([]byte, error) {
 		return t.checksum, nil
 	}
 
-	hasher := sha256.New()
-
-	// Hash the backend name
-	hasher.Write([]byte(t.ID.backend))
-
-	// Hash the input files
-	for _, file := range t.Inputs {
-		fileBytes, err := os.ReadFile(file)
-		if err != nil {
-			return nil, fmt.Errorf("failed to hash input file %s: %w", file, err)
-		}
-
-		hasher.Write(fileBytes)
-	}
-
-	// Hash the parameters
-	paramsJSON, err := t.Params.MarshalJSON()
+	checksum, err := t.digest()
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal task params: %w", err)
+		return nil, err
 	}
 
-	hasher.Write(paramsJSON)
-
 	// Cache the checksum for next access
-	t.checksum = hasher.Sum(nil)
+	t.checksum = checksum
 
 	return t.checksum, nil
 }
 
+// Records the checksum of the task's inputs along with the hashes of its outputs.
 func (t *Task) SaveChecksum() error {
 	checksum, err := t.GenerateChecksum()
 	if err != nil {
 		return err
 	}
 
-	checksumString := base64.StdEncoding.EncodeToString(checksum)
+	err = t.SaveOutputHashes()
+	if err != nil {
+		return err
+	}
+
+	err = t.saveManifest()
+	if err != nil {
+		return err
+	}
+
+	checksumString := fmt.Sprintf(
+		"%d:%s",
+		ChecksumVersion,
+		base64.StdEncoding.EncodeToString(checksum),
+	)
 
 	err = os.WriteFile(
 		t.ID.GetChecksumFile(),
@@ -128,15 +171,36 @@ func (t *Task) SaveChecksum() error {
 	return nil
 }
 
+// Returns whether the task is up to date: its inputs match the saved checksum, and its outputs
+// haven't been modified or removed since.
 func (t *Task) CheckChecksum() bool {
-	savedChecksum, _ := t.ID.LoadChecksum()
-	if savedChecksum != nil {
+	version, savedChecksum, err := t.ID.LoadChecksum()
+	if err != nil {
+		return false
+	}
+
+	switch version {
+	case ChecksumVersion:
 		newChecksum, _ := t.GenerateChecksum()
 
-		if reflect.DeepEqual(savedChecksum, newChecksum) {
-			return true
+		return bytes.Equal(savedChecksum, newChecksum) && t.CheckOutputs()
+	case 1:
+		// Checksums from before versioning were saved without output hashes, so there are no
+		// outputs to verify until the checksum is migrated
+		legacyChecksum, _ := t.legacyDigest()
+		if !bytes.Equal(savedChecksum, legacyChecksum) {
+			return false
 		}
-	}
 
-	return false
+		// Still up to date, so record the checksum and output hashes in the current scheme
+		// instead of rebuilding
+		err = t.SaveChecksum()
+		if err != nil {
+			slog.Warn("failed to migrate checksum", "task", t.ID.String(), "error", err)
+		}
+
+		return true
+	default:
+		return false
+	}
 }
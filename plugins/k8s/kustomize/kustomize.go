@@ -4,9 +4,11 @@
 package main // import "go.bonk.build/plugins/k8s/kustomize"
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path"
+	"strconv"
 
 	"sigs.k8s.io/kustomize/api/konfig"
 	"sigs.k8s.io/kustomize/api/krusty"
@@ -23,7 +25,7 @@ type Params struct {
 	Kustomization types.Kustomization `json:"-"`
 }
 
-func kustomize(params *plugin.TaskParams[Params]) error {
+func kustomize(ctx context.Context, params *plugin.TaskParams[Params]) (*plugin.TaskResult, error) {
 	// Apply resources and any needed fixes
 	params.Params.Kustomization.Resources = params.Inputs
 	params.Params.Kustomization.FixKustomization()
@@ -31,23 +33,29 @@ func kustomize(params *plugin.TaskParams[Params]) error {
 	// Write out the kustomization.yaml file
 	outFile, err := os.Create(path.Join(params.OutDir, konfig.DefaultKustomizationFileName()))
 	if err != nil {
-		return fmt.Errorf("failed to open kustomization file: %w", err)
+		return nil, fmt.Errorf("failed to open kustomization file: %w", err)
 	}
 
 	enc := yaml.NewEncoder(outFile)
 
 	err = enc.Encode(params.Params.Kustomization)
 	if err != nil {
-		return fmt.Errorf("failed to encode kustomization file as yaml: %w", err)
+		return nil, fmt.Errorf("failed to encode kustomization file as yaml: %w", err)
 	}
 
 	err = enc.Close()
 	if err != nil {
-		return fmt.Errorf("failed to close yaml encoder: %w", err)
+		return nil, fmt.Errorf("failed to close yaml encoder: %w", err)
 	}
 	err = outFile.Close()
 	if err != nil {
-		return fmt.Errorf("failed to close yaml file writer: %w", err)
+		return nil, fmt.Errorf("failed to close yaml file writer: %w", err)
+	}
+
+	// Bail out before the expensive part if the build was cancelled
+	err = ctx.Err()
+	if err != nil {
+		return nil, fmt.Errorf("kustomization cancelled: %w", err)
 	}
 
 	// Perform the kustomization
@@ -57,21 +65,29 @@ func kustomize(params *plugin.TaskParams[Params]) error {
 
 	res, err := kusty.Run(filesys.MakeFsOnDisk(), params.OutDir)
 	if err != nil {
-		return fmt.Errorf("failed to perform kustomization: %w", err)
+		return nil, fmt.Errorf("failed to perform kustomization: %w", err)
 	}
 
 	// Save the result
 	resYaml, err := res.AsYaml()
 	if err != nil {
-		return fmt.Errorf("failed to encode kustomized content as yaml: %w", err)
+		return nil, fmt.Errorf("failed to encode kustomized content as yaml: %w", err)
 	}
 
 	err = os.WriteFile(path.Join(params.OutDir, output), resYaml, 0o600)
 	if err != nil {
-		return fmt.Errorf("failed to write kustomized content to file: %w", err)
+		return nil, fmt.Errorf("failed to write kustomized content to file: %w", err)
 	}
 
-	return nil
+	return &plugin.TaskResult{
+		Outputs: []string{
+			konfig.DefaultKustomizationFileName(),
+			output,
+		},
+		Values: map[string]string{
+			"resources": strconv.Itoa(res.Size()),
+		},
+	}, nil
 }
 
 func main() {
@@ -79,6 +95,7 @@ func main() {
 		plugin.NewBackend(
 			"Kustomize",
 			[]string{
+				konfig.DefaultKustomizationFileName(),
 				output,
 			},
 			kustomize,
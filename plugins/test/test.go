@@ -4,6 +4,9 @@
 package main // import "go.bonk.build/plugins/test"
 
 import (
+	"context"
+	"fmt"
+
 	plugin "go.bonk.build/api/go"
 )
 
@@ -11,13 +14,32 @@ type Params struct {
 	Value int `json:"value"`
 }
 
+type Config struct {
+	Count int `json:"count"`
+}
+
 func main() {
 	plugin.Serve(
 		plugin.NewBackend(
 			"Test",
 			[]string{},
-			func(param *plugin.TaskParams[Params]) error {
-				return nil
+			func(_ context.Context, param *plugin.TaskParams[Params]) (*plugin.TaskResult, error) {
+				return nil, nil
+			},
+		),
+		plugin.NewFrontend(
+			"Test",
+			func(_ context.Context, config *Config) ([]plugin.TaskDescription, error) {
+				tasks := make([]plugin.TaskDescription, config.Count)
+				for idx := range tasks {
+					tasks[idx] = plugin.TaskDescription{
+						ID:      fmt.Sprintf("Test.%d", idx),
+						Backend: "test:Test",
+						Params:  Params{Value: idx},
+					}
+				}
+
+				return tasks, nil
 			},
 		),
 	)